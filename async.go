@@ -9,18 +9,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"sync"
 )
 
+// Async - runs callFunc in a goroutine, a recovered panic is passed to errFunc.
+// The goroutine is registered in the tracker set by SetAsyncTracker.
 func Async(callFunc func(), errFunc func(err error)) {
+	if t := asyncTracker.Load(); t != nil {
+		(*t).Async("", callFunc, errFunc)
+		return
+	}
+	async(callFunc, errFunc, nil)
+}
+
+func async(callFunc func(), errFunc func(err error), done func()) {
 	go func() {
+		if done != nil {
+			defer done()
+		}
 		defer func() {
 			if e := recover(); e != nil {
+				err := newPanicError(e, "go.osspkg.com/do.Async", "go.osspkg.com/do.async")
 				if errFunc != nil {
-					err := fmt.Errorf(
-						"panic=%w trace=%s",
-						fmt.Errorf("%+v", e),
-						Trace(4, 1, "go.osspkg.com/do.Async"))
 					errFunc(err)
 				}
 			}
@@ -32,34 +44,211 @@ func Async(callFunc func(), errFunc func(err error)) {
 }
 
 func AsyncGroup(ctx context.Context, callFuncs ...func(ctx context.Context) error) []error {
+	return groupErrors(asyncGroup(ctx, asyncGroupConfig{}, callFuncs))
+}
+
+// AsyncGroupLimit - like AsyncGroup, but runs at most limit callbacks at once.
+// Callbacks that were not started before ctx is done are reported with ctx.Err().
+func AsyncGroupLimit(ctx context.Context, limit int, callFuncs ...func(ctx context.Context) error) []error {
+	return groupErrors(asyncGroup(ctx, asyncGroupConfig{limit: limit}, callFuncs))
+}
+
+// AsyncGroupFailFast - like AsyncGroup, but the first error or panic cancels the context
+// passed to the other callbacks. The first error goes first in the result.
+func AsyncGroupFailFast(ctx context.Context, callFuncs ...func(ctx context.Context) error) []error {
+	return groupErrors(asyncGroup(ctx, asyncGroupConfig{failFast: true}, callFuncs))
+}
+
+// AsyncMap - calls fn for every element of in concurrently and returns results in input order.
+// Errors are returned per index, the error slice is nil when all calls succeeded.
+func AsyncMap[T, R any](ctx context.Context, in []T, fn func(ctx context.Context, value T) (R, error), opts ...AsyncOption) ([]R, []error) {
+	conf := asyncGroupConfig{}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	out := make([]R, len(in))
+	started := make([]bool, len(in))
+	callFuncs := make([]func(ctx context.Context) error, len(in))
+	for i, value := range in {
+		callFuncs[i] = func(ctx context.Context) (err error) {
+			started[i] = true
+			out[i], err = fn(ctx, value)
+			return
+		}
+	}
+
+	taskErrs := asyncGroup(ctx, conf, callFuncs)
+	if len(taskErrs) == 0 {
+		return out, nil
+	}
+
+	errs := make([]error, len(in))
+	for _, te := range taskErrs {
+		errs[te.Index] = te.Err
+	}
+	for i, ok := range started {
+		if !ok && errs[i] == nil {
+			errs[i] = context.Canceled
+		}
+	}
+	return out, errs
+}
+
+type AsyncOption func(conf *asyncGroupConfig)
+
+// AsyncLimit - runs at most limit callbacks at once.
+func AsyncLimit(limit int) AsyncOption {
+	return func(conf *asyncGroupConfig) {
+		conf.limit = limit
+	}
+}
+
+// AsyncFailFast - cancels the context of the other callbacks on the first error or panic.
+func AsyncFailFast() AsyncOption {
+	return func(conf *asyncGroupConfig) {
+		conf.failFast = true
+	}
+}
+
+// AsyncGroupRateLimit - like AsyncGroup, but starts callbacks no faster than limiter allows.
+// Callbacks that were not started before ctx is done are reported with ctx.Err().
+func AsyncGroupRateLimit(ctx context.Context, limiter RateLimiter, callFuncs ...func(ctx context.Context) error) []error {
+	return groupErrors(asyncGroup(ctx, asyncGroupConfig{limiter: limiter}, callFuncs))
+}
+
+// AsyncRateLimit - starts callbacks no faster than limiter allows.
+func AsyncRateLimit(limiter RateLimiter) AsyncOption {
+	return func(conf *asyncGroupConfig) {
+		conf.limiter = limiter
+	}
+}
+
+type asyncGroupConfig struct {
+	limit    int
+	failFast bool
+	limiter  RateLimiter
+}
+
+// TaskError - error of a single callback of the group, Index is the callback position in the call.
+type TaskError struct {
+	Index int
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task #%d: %s", e.Index, e.Err.Error())
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// JoinTaskErrors - joins group errors into one error ordered by task index.
+func JoinTaskErrors(errs []error) error {
+	errs = slices.Clone(errs)
+	slices.SortStableFunc(errs, func(a, b error) int {
+		return taskIndex(a) - taskIndex(b)
+	})
+	return errors.Join(errs...)
+}
+
+func taskIndex(err error) int {
+	var te *TaskError
+	if errors.As(err, &te) {
+		return te.Index
+	}
+	return math.MaxInt
+}
+
+func groupErrors(taskErrs []*TaskError) []error {
+	errs := make([]error, 0, len(taskErrs))
+	for _, te := range taskErrs {
+		errs = append(errs, te)
+	}
+	return errs
+}
+
+func asyncGroup(ctx context.Context, conf asyncGroupConfig, callFuncs []func(ctx context.Context) error) []*TaskError {
 	var wg sync.WaitGroup
-	errC := make(chan error, len(callFuncs))
+	errC := make(chan *TaskError, len(callFuncs))
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	wg.Add(len(callFuncs))
-	for _, callFunc := range callFuncs {
-		callFunc := callFunc
+	var sem chan struct{}
+	if conf.limit > 0 && conf.limit < len(callFuncs) {
+		sem = make(chan struct{}, conf.limit)
+	}
+
+	for i, callFunc := range callFuncs {
+		if sem != nil || conf.limiter != nil {
+			if err := acquire(runCtx, sem, conf.limiter); err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				} else if runCtx.Err() != nil {
+					break
+				}
+				for j := i; j < len(callFuncs); j++ {
+					errC <- &TaskError{Index: j, Err: err}
+				}
+				break
+			}
+		}
+
+		wg.Add(1)
 		go func() {
 			var err error
 			defer func() {
 				if e := recover(); e != nil {
-					err = errors.Join(err, fmt.Errorf("%+v", e))
+					err = newPanicError(e, "go.osspkg.com/do.AsyncGroup", "go.osspkg.com/do.asyncGroup")
 				}
 				if err != nil {
-					errC <- err
+					errC <- &TaskError{Index: i, Err: err}
+					if conf.failFast {
+						cancel()
+					}
+				}
+				if sem != nil {
+					<-sem
 				}
 				wg.Done()
 			}()
-			err = callFunc(ctx)
+			err = callFunc(IfElse(conf.failFast, runCtx, ctx))
 		}()
 	}
 
 	wg.Wait()
 	close(errC)
 
-	errGrp := make([]error, 0, len(callFuncs))
-	for err := range errC {
-		errGrp = append(errGrp, err)
+	taskErrs := make([]*TaskError, 0, len(callFuncs))
+	for te := range errC {
+		taskErrs = append(taskErrs, te)
 	}
 
-	return errGrp
+	return taskErrs
+}
+
+func acquire(ctx context.Context, sem chan struct{}, limiter RateLimiter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if sem == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case sem <- struct{}{}:
+		if err := ctx.Err(); err != nil {
+			<-sem
+			return err
+		}
+		return nil
+	}
 }
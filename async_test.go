@@ -9,7 +9,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sort"
+	"io"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -29,6 +30,29 @@ func TestUnit_Recovery(t *testing.T) {
 	casecheck.Contains(t, err.Error(), "panic=1 trace=./async_test.go:")
 	casecheck.Contains(t, err.Error(), "go.osspkg.com/do_test.TestUnit_Recovery.func1.1")
 	casecheck.Equal(t, "1", errors.Unwrap(err).Error())
+
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, 1, pe.Value)
+	casecheck.Contains(t, pe.Goroutine, "goroutine ")
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Recovery.func1.1", pe.Stack[0].Function)
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Recovery.func1", pe.Stack[1].Function)
+	for _, f := range pe.Stack {
+		casecheck.False(t, strings.Contains(f.Function, "go.osspkg.com/do.Recovery"))
+	}
+	casecheck.Contains(t, fmt.Sprintf("%+v", err), "panic=1\n"+pe.Goroutine+":\n./async_test.go:")
+
+	err = do.Recovery(func() {
+		var m map[string]int
+		m["a"] = 1
+	})
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Recovery.func2", pe.Stack[0].Function)
+
+	err = do.Recovery(func() {
+		panic(io.EOF)
+	})
+	casecheck.True(t, errors.Is(err, io.EOF))
 }
 
 func TestUnit_Async(t *testing.T) {
@@ -59,10 +83,157 @@ func TestUnit_AsyncGroup(t *testing.T) {
 			return fmt.Errorf("good")
 		},
 	)
-	var strErrs []string
+	casecheck.Equal(t, 3, len(errs))
+	for _, err := range errs {
+		var te *do.TaskError
+		casecheck.True(t, errors.As(err, &te))
+		if te.Index == 2 {
+			casecheck.Equal(t, "task #2: good", err.Error())
+			continue
+		}
+
+		var pe *do.PanicError
+		casecheck.True(t, errors.As(err, &pe))
+		casecheck.Equal(t, te.Index+1, pe.Value)
+		casecheck.Contains(t, err.Error(), fmt.Sprintf("task #%d: panic=%d trace=./async_test.go:", te.Index, te.Index+1))
+		casecheck.Contains(t, err.Error(), fmt.Sprintf("go.osspkg.com/do_test.TestUnit_AsyncGroup.func%d", te.Index+1))
+	}
+}
+
+func TestUnit_AsyncGroupLimit(t *testing.T) {
+	var active, peak int64
+	calls := make([]func(ctx context.Context) error, 0, 20)
+	for i := 0; i < 20; i++ {
+		calls = append(calls, func(ctx context.Context) error {
+			n := atomic.AddInt64(&active, 1)
+			defer atomic.AddInt64(&active, -1)
+			for {
+				p := atomic.LoadInt64(&peak)
+				if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if i%5 == 0 {
+				panic(i)
+			}
+			return nil
+		})
+	}
+
+	errs := do.AsyncGroupLimit(context.TODO(), 3, calls...)
+	casecheck.Equal(t, 4, len(errs))
+	casecheck.True(t, atomic.LoadInt64(&peak) <= 3)
+}
+
+func TestUnit_AsyncGroupLimitCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	var started int64
+	calls := make([]func(ctx context.Context) error, 0, 10)
+	for i := 0; i < 10; i++ {
+		calls = append(calls, func(ctx context.Context) error {
+			if atomic.AddInt64(&started, 1) == 2 {
+				cancel()
+			}
+			return nil
+		})
+	}
+
+	errs := do.AsyncGroupLimit(ctx, 1, calls...)
+	casecheck.Equal(t, int64(2), atomic.LoadInt64(&started))
+	casecheck.Equal(t, 8, len(errs))
 	for _, err := range errs {
-		strErrs = append(strErrs, err.Error())
+		casecheck.True(t, errors.Is(err, context.Canceled))
 	}
-	sort.Strings(strErrs)
-	casecheck.Equal(t, []string{"1", "2", "good"}, strErrs)
+}
+
+func TestUnit_AsyncGroupFailFast(t *testing.T) {
+	errs := do.AsyncGroupFailFast(
+		context.TODO(),
+		func(ctx context.Context) error {
+			return fmt.Errorf("first")
+		},
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return fmt.Errorf("second: %w", ctx.Err())
+		},
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+	)
+	casecheck.Equal(t, 2, len(errs))
+	casecheck.Equal(t, "task #0: first", errs[0].Error())
+	casecheck.Equal(t, "task #1: second: context canceled", errs[1].Error())
+
+	errs = do.AsyncGroupFailFast(
+		context.TODO(),
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+		func(ctx context.Context) error {
+			panic(1)
+		},
+	)
+	casecheck.Equal(t, 1, len(errs))
+	casecheck.Contains(t, errs[0].Error(), "task #1: panic=1 trace=./async_test.go:")
+}
+
+func TestUnit_AsyncMap(t *testing.T) {
+	out, errs := do.AsyncMap(context.TODO(), []int{1, 2, 3, 4}, func(ctx context.Context, value int) (string, error) {
+		time.Sleep(time.Duration(5-value) * time.Millisecond)
+		return fmt.Sprintf("v%d", value), nil
+	}, do.AsyncLimit(2))
+	casecheck.Equal(t, []string{"v1", "v2", "v3", "v4"}, out)
+	casecheck.True(t, errs == nil)
+
+	out, errs = do.AsyncMap(context.TODO(), []int{1, 2, 3}, func(ctx context.Context, value int) (string, error) {
+		switch value {
+		case 2:
+			return "", fmt.Errorf("fail")
+		case 3:
+			panic("boom")
+		}
+		return "ok", nil
+	})
+	casecheck.Equal(t, []string{"ok", "", ""}, out)
+	casecheck.Equal(t, 3, len(errs))
+	casecheck.NoError(t, errs[0])
+	casecheck.Equal(t, "fail", errs[1].Error())
+	casecheck.Contains(t, errs[2].Error(), "panic=boom trace=./async_test.go:")
+}
+
+func TestUnit_AsyncMapFailFast(t *testing.T) {
+	out, errs := do.AsyncMap(context.TODO(), []int{1, 2, 3}, func(ctx context.Context, value int) (int, error) {
+		if value == 1 {
+			return 0, fmt.Errorf("fail")
+		}
+		return value, nil
+	}, do.AsyncLimit(1), do.AsyncFailFast())
+	casecheck.Equal(t, []int{0, 0, 0}, out)
+	casecheck.Equal(t, 3, len(errs))
+	casecheck.Equal(t, "fail", errs[0].Error())
+	casecheck.True(t, errors.Is(errs[1], context.Canceled))
+	casecheck.True(t, errors.Is(errs[2], context.Canceled))
+}
+
+func TestUnit_JoinTaskErrors(t *testing.T) {
+	errs := []error{
+		&do.TaskError{Index: 2, Err: io.EOF},
+		&do.TaskError{Index: 0, Err: fmt.Errorf("first")},
+		&do.TaskError{Index: 1, Err: fmt.Errorf("second")},
+	}
+	err := do.JoinTaskErrors(errs)
+	casecheck.Equal(t, "task #0: first\ntask #1: second\ntask #2: EOF", err.Error())
+	casecheck.True(t, errors.Is(err, io.EOF))
+	casecheck.Equal(t, 2, errs[0].(*do.TaskError).Index)
+
+	var te *do.TaskError
+	casecheck.True(t, errors.As(err, &te))
+	casecheck.Equal(t, 0, te.Index)
+
+	casecheck.NoError(t, do.JoinTaskErrors(nil))
 }
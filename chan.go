@@ -0,0 +1,182 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// All channel helpers stop their goroutines and close the output channels
+// once the input is closed or ctx is done.
+
+func send[T any](ctx context.Context, out chan<- T, value T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- value:
+		return true
+	}
+}
+
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	return Buffer(ctx, in, 0)
+}
+
+func Buffer[T any](ctx context.Context, in <-chan T, size int) <-chan T {
+	out := make(chan T, max(size, 0))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func() {
+			defer wg.Done()
+			for v := range OrDone(ctx, in) {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Broadcast - sends every value of in to each of count outputs, a slow reader holds back the others.
+func Broadcast[T any](ctx context.Context, in <-chan T, count int) []<-chan T {
+	outs := make([]chan T, max(count, 0))
+	result := make([]<-chan T, len(outs))
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for v := range OrDone(ctx, in) {
+			for _, out := range outs {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return result
+}
+
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	outs := Broadcast(ctx, in, 2)
+	return outs[0], outs[1]
+}
+
+// BatchByCountOrTime - groups values into batches of count,
+// an incomplete batch is sent when interval has passed since its first value.
+func BatchByCountOrTime[T any](ctx context.Context, in <-chan T, count int, interval time.Duration) <-chan []T {
+	count = max(count, 1)
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		var (
+			batch []T
+			timer *time.Timer
+			tick  <-chan time.Time
+		)
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, tick = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			b := batch
+			batch = nil
+			return send(ctx, out, b)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+				if !flush() {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 {
+					batch = make([]T, 0, count)
+					timer = time.NewTimer(interval)
+					tick = timer.C
+				}
+				batch = append(batch, v)
+				if len(batch) >= count && !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+func FromSlice[T any](ctx context.Context, in []T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range in {
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ToSliceChan - reads in until it is closed or ctx is done.
+func ToSliceChan[T any](ctx context.Context, in <-chan T) (out []T) {
+	for v := range OrDone(ctx, in) {
+		out = append(out, v)
+	}
+	return
+}
@@ -0,0 +1,101 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_FromSliceToSliceChan(t *testing.T) {
+	ctx := context.TODO()
+	casecheck.Equal(t, []int{1, 2, 3}, do.ToSliceChan(ctx, do.FromSlice(ctx, []int{1, 2, 3})))
+	casecheck.Equal(t, []int{1, 2, 3}, do.ToSliceChan(ctx, do.Buffer(ctx, do.FromSlice(ctx, []int{1, 2, 3}), 2)))
+}
+
+func TestUnit_Merge(t *testing.T) {
+	ctx := context.TODO()
+	out := do.ToSliceChan(ctx, do.Merge(ctx,
+		do.FromSlice(ctx, []int{1, 2}),
+		do.FromSlice(ctx, []int{3}),
+		do.FromSlice(ctx, []int{4, 5}),
+	))
+	sort.Ints(out)
+	casecheck.Equal(t, []int{1, 2, 3, 4, 5}, out)
+}
+
+func TestUnit_Broadcast(t *testing.T) {
+	ctx := context.TODO()
+	a, b := do.Tee(ctx, do.FromSlice(ctx, []int{1, 2, 3}))
+
+	var wg sync.WaitGroup
+	var outA, outB []int
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		outA = do.ToSliceChan(ctx, a)
+	}()
+	go func() {
+		defer wg.Done()
+		outB = do.ToSliceChan(ctx, b)
+	}()
+	wg.Wait()
+
+	casecheck.Equal(t, []int{1, 2, 3}, outA)
+	casecheck.Equal(t, []int{1, 2, 3}, outB)
+}
+
+func TestUnit_BatchByCountOrTime(t *testing.T) {
+	ctx := context.TODO()
+	out := do.ToSliceChan(ctx, do.BatchByCountOrTime(ctx, do.FromSlice(ctx, []int{1, 2, 3, 4, 5}), 2, time.Hour))
+	casecheck.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, out)
+
+	in := make(chan int)
+	batches := do.BatchByCountOrTime(ctx, in, 10, 10*time.Millisecond)
+	in <- 1
+	in <- 2
+	casecheck.Equal(t, []int{1, 2}, <-batches)
+	in <- 3
+	close(in)
+	casecheck.Equal(t, []int{3}, <-batches)
+	_, ok := <-batches
+	casecheck.False(t, ok)
+}
+
+func TestUnit_ChanCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	in := make(chan int)
+	outs := []<-chan int{
+		do.OrDone(ctx, in),
+		do.Merge(ctx, in, in),
+	}
+	outs = append(outs, do.Broadcast(ctx, do.FromSlice(ctx, []int{1}), 3)...)
+	batches := do.BatchByCountOrTime(ctx, do.FromSlice(ctx, []int{1, 2, 3}), 2, time.Hour)
+	cancel()
+
+	for _, out := range outs {
+		for range out {
+		}
+	}
+	for range batches {
+	}
+	casecheck.Equal(t, 0, len(do.ToSliceChan(ctx, in)))
+
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	casecheck.True(t, runtime.NumGoroutine() <= before)
+}
@@ -0,0 +1,228 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type (
+	CircuitState int
+
+	// CircuitBreakerConfig - the circuit opens after ConsecutiveFailures failures in a row
+	// or when the share of failures reaches FailureRatio after at least MinRequests calls.
+	// Counters of the closed state are reset every Interval if it is set.
+	// With no thresholds set the circuit opens after 5 consecutive failures.
+	CircuitBreakerConfig struct {
+		ConsecutiveFailures int
+		FailureRatio        float64
+		MinRequests         int
+		Interval            time.Duration
+		// Cooldown - time in the open state before probe calls are allowed.
+		Cooldown time.Duration
+		// HalfOpenProbes - max concurrent probe calls, the circuit closes after as many successes.
+		HalfOpenProbes int
+		OnStateChange  func(from, to CircuitState)
+		Clock          Clock
+	}
+
+	CircuitBreaker interface {
+		Execute(ctx context.Context, fn func(ctx context.Context) error) error
+		State() CircuitState
+	}
+
+	_circuitBreaker struct {
+		conf  CircuitBreakerConfig
+		clock Clock
+
+		state       CircuitState
+		generation  uint64
+		changedAt   time.Time
+		requests    int
+		failures    int
+		consecutive int
+		successes   int
+		probes      int
+		mux         sync.Mutex
+	}
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+func NewCircuitBreaker(conf CircuitBreakerConfig) CircuitBreaker {
+	if conf.ConsecutiveFailures <= 0 && conf.FailureRatio <= 0 {
+		conf.ConsecutiveFailures = 5
+	}
+	conf.HalfOpenProbes = max(conf.HalfOpenProbes, 1)
+
+	cb := &_circuitBreaker{
+		conf:  conf,
+		clock: clockOrSystem(conf.Clock),
+	}
+	cb.changedAt = cb.clock.Now()
+	return cb
+}
+
+func (cb *_circuitBreaker) State() CircuitState {
+	cb.mux.Lock()
+	state, changes := cb.current(cb.clock.Now())
+	cb.mux.Unlock()
+
+	cb.notify(changes)
+	return state
+}
+
+// Execute - runs fn if the circuit allows it, a panic in fn counts as a failure.
+func (cb *_circuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	generation, err := cb.before()
+	if err != nil {
+		return err
+	}
+
+	if e := Recovery(func() {
+		err = fn(ctx)
+	}); e != nil {
+		err = e
+	}
+
+	cb.after(generation, err == nil)
+	return err
+}
+
+func (cb *_circuitBreaker) before() (uint64, error) {
+	cb.mux.Lock()
+	state, changes := cb.current(cb.clock.Now())
+	defer func() {
+		cb.mux.Unlock()
+		cb.notify(changes)
+	}()
+
+	switch state {
+	case CircuitOpen:
+		return 0, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.probes >= cb.conf.HalfOpenProbes {
+			return 0, ErrCircuitOpen
+		}
+		cb.probes++
+	}
+
+	cb.requests++
+	return cb.generation, nil
+}
+
+func (cb *_circuitBreaker) after(generation uint64, success bool) {
+	cb.mux.Lock()
+	now := cb.clock.Now()
+	state, changes := cb.current(now)
+	defer func() {
+		cb.mux.Unlock()
+		cb.notify(changes)
+	}()
+
+	if generation != cb.generation {
+		return
+	}
+
+	switch state {
+	case CircuitClosed:
+		if success {
+			cb.consecutive = 0
+			return
+		}
+		cb.failures++
+		cb.consecutive++
+		if cb.tripped() {
+			changes = append(changes, cb.setState(CircuitOpen, now))
+		}
+
+	case CircuitHalfOpen:
+		cb.probes--
+		if !success {
+			changes = append(changes, cb.setState(CircuitOpen, now))
+			return
+		}
+		cb.successes++
+		if cb.successes >= cb.conf.HalfOpenProbes {
+			changes = append(changes, cb.setState(CircuitClosed, now))
+		}
+	}
+}
+
+func (cb *_circuitBreaker) tripped() bool {
+	if cb.conf.ConsecutiveFailures > 0 && cb.consecutive >= cb.conf.ConsecutiveFailures {
+		return true
+	}
+	if cb.conf.FailureRatio > 0 && cb.requests >= max(cb.conf.MinRequests, 1) {
+		return float64(cb.failures)/float64(cb.requests) >= cb.conf.FailureRatio
+	}
+	return false
+}
+
+func (cb *_circuitBreaker) current(now time.Time) (CircuitState, [][2]CircuitState) {
+	var changes [][2]CircuitState
+
+	switch cb.state {
+	case CircuitClosed:
+		if cb.conf.Interval > 0 && now.Sub(cb.changedAt) >= cb.conf.Interval {
+			cb.reset(now)
+		}
+	case CircuitOpen:
+		if now.Sub(cb.changedAt) >= cb.conf.Cooldown {
+			changes = append(changes, cb.setState(CircuitHalfOpen, now))
+		}
+	}
+
+	return cb.state, changes
+}
+
+func (cb *_circuitBreaker) setState(state CircuitState, now time.Time) [2]CircuitState {
+	prev := cb.state
+	cb.state = state
+	cb.generation++
+	cb.reset(now)
+	return [2]CircuitState{prev, state}
+}
+
+func (cb *_circuitBreaker) reset(now time.Time) {
+	cb.changedAt = now
+	cb.requests = 0
+	cb.failures = 0
+	cb.consecutive = 0
+	cb.successes = 0
+	cb.probes = 0
+}
+
+func (cb *_circuitBreaker) notify(changes [][2]CircuitState) {
+	if cb.conf.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		cb.conf.OnStateChange(c[0], c[1])
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_CircuitBreaker(t *testing.T) {
+	clock := newFakeClock(false)
+	var changes []string
+	cb := do.NewCircuitBreaker(do.CircuitBreakerConfig{
+		ConsecutiveFailures: 2,
+		Cooldown:            time.Minute,
+		HalfOpenProbes:      2,
+		Clock:               clock,
+		OnStateChange: func(from, to do.CircuitState) {
+			changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+		},
+	})
+
+	fail := func(ctx context.Context) error { return io.EOF }
+	ok := func(ctx context.Context) error { return nil }
+
+	casecheck.Equal(t, do.CircuitClosed, cb.State())
+	casecheck.True(t, errors.Is(cb.Execute(context.TODO(), fail), io.EOF))
+	casecheck.NoError(t, cb.Execute(context.TODO(), ok))
+	casecheck.True(t, errors.Is(cb.Execute(context.TODO(), fail), io.EOF))
+	casecheck.Equal(t, do.CircuitClosed, cb.State())
+
+	err := cb.Execute(context.TODO(), func(ctx context.Context) error {
+		panic("boom")
+	})
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, do.CircuitOpen, cb.State())
+	casecheck.True(t, errors.Is(cb.Execute(context.TODO(), ok), do.ErrCircuitOpen))
+
+	clock.Advance(time.Minute)
+	casecheck.Equal(t, do.CircuitHalfOpen, cb.State())
+	casecheck.True(t, errors.Is(cb.Execute(context.TODO(), fail), io.EOF))
+	casecheck.Equal(t, do.CircuitOpen, cb.State())
+
+	clock.Advance(time.Minute)
+	casecheck.NoError(t, cb.Execute(context.TODO(), ok))
+	casecheck.Equal(t, do.CircuitHalfOpen, cb.State())
+	casecheck.NoError(t, cb.Execute(context.TODO(), ok))
+	casecheck.Equal(t, do.CircuitClosed, cb.State())
+
+	casecheck.Equal(t, []string{
+		"closed->open", "open->half-open", "half-open->open",
+		"open->half-open", "half-open->closed",
+	}, changes)
+}
+
+func TestUnit_CircuitBreakerProbes(t *testing.T) {
+	clock := newFakeClock(false)
+	cb := do.NewCircuitBreaker(do.CircuitBreakerConfig{
+		ConsecutiveFailures: 1,
+		Clock:               clock,
+	})
+	casecheck.Error(t, cb.Execute(context.TODO(), func(ctx context.Context) error { return io.EOF }))
+	casecheck.Equal(t, do.CircuitHalfOpen, cb.State())
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = cb.Execute(context.TODO(), func(ctx context.Context) error { //nolint:errcheck
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+	casecheck.True(t, errors.Is(cb.Execute(context.TODO(), func(ctx context.Context) error { return nil }), do.ErrCircuitOpen))
+	close(release)
+	for cb.State() != do.CircuitClosed {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestUnit_CircuitBreakerRatio(t *testing.T) {
+	clock := newFakeClock(false)
+	cb := do.NewCircuitBreaker(do.CircuitBreakerConfig{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		Interval:     time.Minute,
+		Cooldown:     time.Second,
+		Clock:        clock,
+	})
+	fail := func(ctx context.Context) error { return io.EOF }
+	ok := func(ctx context.Context) error { return nil }
+
+	casecheck.Error(t, cb.Execute(context.TODO(), fail))
+	casecheck.Error(t, cb.Execute(context.TODO(), fail))
+	casecheck.NoError(t, cb.Execute(context.TODO(), ok))
+	casecheck.Equal(t, do.CircuitClosed, cb.State())
+
+	clock.Advance(time.Minute)
+	casecheck.NoError(t, cb.Execute(context.TODO(), ok))
+	casecheck.NoError(t, cb.Execute(context.TODO(), ok))
+	casecheck.Error(t, cb.Execute(context.TODO(), fail))
+	casecheck.Equal(t, do.CircuitClosed, cb.State())
+	casecheck.Error(t, cb.Execute(context.TODO(), fail))
+	casecheck.Equal(t, do.CircuitOpen, cb.State())
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	Clock interface {
+		Now() time.Time
+		NewTimer(d time.Duration) Timer
+	}
+
+	Timer interface {
+		C() <-chan time.Time
+		Stop() bool
+	}
+
+	_systemClock struct{}
+	_systemTimer struct {
+		timer *time.Timer
+	}
+)
+
+func SystemClock() Clock {
+	return _systemClock{}
+}
+
+func (_systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (_systemClock) NewTimer(d time.Duration) Timer {
+	return &_systemTimer{timer: time.NewTimer(d)}
+}
+
+func (t *_systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *_systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock()
+	}
+	return c
+}
+
+func sleep(ctx context.Context, c Clock, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := c.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+// fakeClock - manual clock for tests, in auto mode every new timer moves the time forward and fires at once.
+type fakeClock struct {
+	mux    sync.Mutex
+	now    time.Time
+	auto   bool
+	timers []*fakeTimer
+	delays []time.Duration
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func newFakeClock(auto bool) *fakeClock {
+	return &fakeClock{
+		now:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		auto: auto,
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) do.Timer {
+	c.mux.Lock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.delays = append(c.delays, d)
+	c.timers = append(c.timers, t)
+	auto := c.auto
+	c.mux.Unlock()
+
+	if auto || d <= 0 {
+		c.Advance(max(d, 0))
+	}
+	return t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.now = c.now.Add(d)
+	active := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			active = append(active, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = active
+}
+
+func (c *fakeClock) Delays() []time.Duration {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return append([]time.Duration(nil), c.delays...)
+}
+
+// WaitTimers - waits until n timers are pending.
+func (c *fakeClock) WaitTimers(n int) {
+	for {
+		c.mux.Lock()
+		count := len(c.timers)
+		c.mux.Unlock()
+		if count >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mux.Lock()
+	defer t.clock.mux.Unlock()
+
+	for i, v := range t.clock.timers {
+		if v == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func TestUnit_SystemClock(t *testing.T) {
+	c := do.SystemClock()
+	start := c.Now()
+	timer := c.NewTimer(time.Millisecond)
+	<-timer.C()
+	casecheck.True(t, c.Now().Sub(start) >= time.Millisecond)
+	casecheck.False(t, timer.Stop())
+}
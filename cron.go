@@ -0,0 +1,226 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	Schedule interface {
+		// Next - returns the first activation time after t, zero time if there is none.
+		Next(t time.Time) time.Time
+	}
+
+	_every struct {
+		interval time.Duration
+	}
+
+	_dailyAt struct {
+		hour, minute, second int
+	}
+
+	_cron struct {
+		minute, hour, dom, month, dow uint64
+		domStar, dowStar              bool
+	}
+
+	_cronField struct {
+		min, max int
+		names    map[string]int
+	}
+)
+
+var (
+	cronMinute = _cronField{min: 0, max: 59}
+	cronHour   = _cronField{min: 0, max: 23}
+	cronDom    = _cronField{min: 1, max: 31}
+	cronMonth  = _cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDow = _cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// Every - runs with the fixed interval, zero or negative interval means one second.
+func Every(interval time.Duration) Schedule {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &_every{interval: interval}
+}
+
+func (s *_every) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// DailyAt - every day at the given time of day in the location of the checked time.
+func DailyAt(hour, minute, second int) Schedule {
+	return &_dailyAt{
+		hour:   MinMax(hour, 0, 23),
+		minute: MinMax(minute, 0, 59),
+		second: MinMax(second, 0, 59),
+	}
+}
+
+func (s *_dailyAt) Next(t time.Time) time.Time {
+	next := time.Date(t.Year(), t.Month(), t.Day(), s.hour, s.minute, s.second, 0, t.Location())
+	if !next.After(t) {
+		next = time.Date(t.Year(), t.Month(), t.Day()+1, s.hour, s.minute, s.second, 0, t.Location())
+	}
+	return next
+}
+
+// ParseCron - parses a standard 5-field cron expression: minute, hour, day of month, month, day of week.
+// Fields support *, lists, ranges, steps and month or weekday names; @hourly-like macros are supported too.
+func ParseCron(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if v, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = v
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: expected 5 fields, got %d in %q", len(fields), expr)
+	}
+
+	var (
+		c   _cron
+		err error
+	)
+	if c.minute, _, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if c.hour, _, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if c.dom, c.domStar, err = cronDom.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if c.month, _, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.dow, c.dowStar, err = cronDow.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return &c, nil
+}
+
+func (f _cronField) parse(value string) (bits uint64, star bool, err error) {
+	for _, part := range strings.Split(value, ",") {
+		var b uint64
+		if b, err = f.parsePart(part); err != nil {
+			return 0, false, err
+		}
+		bits |= b
+	}
+	// as in Vixie cron, a field starting with "*" (e.g. "*/2") counts as unrestricted for the dom/dow OR rule
+	return bits, strings.HasPrefix(value, "*"), nil
+}
+
+func (f _cronField) parsePart(part string) (uint64, error) {
+	rng, stepStr, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+			return 0, fmt.Errorf("cron: invalid step in %q", part)
+		}
+	}
+
+	start, end := f.min, f.max
+	if rng != "*" {
+		from, to, isRange := strings.Cut(rng, "-")
+		var err error
+		if start, err = f.value(from); err != nil {
+			return 0, fmt.Errorf("cron: %w in %q", err, part)
+		}
+		end = IfElse(hasStep, f.max, start)
+		if isRange {
+			if end, err = f.value(to); err != nil {
+				return 0, fmt.Errorf("cron: %w in %q", err, part)
+			}
+		}
+		if start > end {
+			return 0, fmt.Errorf("cron: invalid range in %q", part)
+		}
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits, nil
+}
+
+func (f _cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (c *_cron) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (c *_cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_ParseCron(t *testing.T) {
+	from := time.Date(2025, 1, 1, 10, 30, 15, 0, time.UTC) // Wednesday
+
+	cases := []struct {
+		expr string
+		next []string
+	}{
+		{expr: "* * * * *", next: []string{"2025-01-01 10:31", "2025-01-01 10:32"}},
+		{expr: "*/15 * * * *", next: []string{"2025-01-01 10:45", "2025-01-01 11:00"}},
+		{expr: "5,10 9-11 * * *", next: []string{"2025-01-01 11:05", "2025-01-01 11:10", "2025-01-02 09:05"}},
+		{expr: "0 0 * * mon", next: []string{"2025-01-06 00:00", "2025-01-13 00:00"}},
+		{expr: "0 12 * * 7", next: []string{"2025-01-05 12:00"}},
+		{expr: "0 0 */2 * mon", next: []string{"2025-01-13 00:00", "2025-01-27 00:00"}},
+		{expr: "0 0 13 * 5", next: []string{"2025-01-03 00:00", "2025-01-10 00:00", "2025-01-13 00:00"}},
+		{expr: "30 4 29 feb *", next: []string{"2028-02-29 04:30"}},
+		{expr: "10/20 0 1 */6 *", next: []string{"2025-07-01 00:10", "2025-07-01 00:30", "2025-07-01 00:50"}},
+		{expr: "@daily", next: []string{"2025-01-02 00:00"}},
+	}
+
+	for _, c := range cases {
+		s, err := do.ParseCron(c.expr)
+		casecheck.NoError(t, err, c.expr)
+
+		next := from
+		for _, expected := range c.next {
+			next = s.Next(next)
+			casecheck.Equal(t, expected, next.Format("2006-01-02 15:04"), c.expr)
+		}
+	}
+
+	s, err := do.ParseCron("0 0 31 2 *")
+	casecheck.NoError(t, err)
+	casecheck.True(t, s.Next(from).IsZero())
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		_, err = do.ParseCron(expr)
+		casecheck.Error(t, err, expr)
+	}
+}
+
+func TestUnit_DailyAt(t *testing.T) {
+	s := do.DailyAt(9, 30, 0)
+	casecheck.Equal(t,
+		time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC),
+		s.Next(time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)))
+	casecheck.Equal(t,
+		time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC),
+		s.Next(time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC)))
+
+	casecheck.Equal(t,
+		time.Date(2025, 1, 1, 9, 31, 0, 0, time.UTC),
+		do.Every(time.Minute).Next(time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC)))
+	casecheck.Equal(t,
+		time.Date(2025, 1, 1, 9, 30, 0, int(100*time.Millisecond), time.UTC),
+		do.Every(100*time.Millisecond).Next(time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC)))
+	casecheck.Equal(t,
+		time.Date(2025, 1, 1, 9, 30, 1, 0, time.UTC),
+		do.Every(0).Next(time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC)))
+}
@@ -0,0 +1,157 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// EdgeOptions - Leading runs fn on the first call of a series, Trailing runs fn after the series.
+	// Errors and recovered panics of fn are passed to ErrFunc. Nil Clock means SystemClock.
+	EdgeOptions struct {
+		Leading  bool
+		Trailing bool
+		ErrFunc  func(err error)
+		Clock    Clock
+	}
+
+	DelayedFunc interface {
+		// Call - starts or extends a series, fn runs in its own goroutine and never blocks the caller.
+		Call()
+		// Flush - runs the pending trailing call right now in the calling goroutine.
+		Flush()
+		// Cancel - drops the pending trailing call.
+		Cancel()
+	}
+
+	_delayedFunc struct {
+		fn       func()
+		wait     time.Duration
+		opts     EdgeOptions
+		clock    Clock
+		throttle bool
+
+		timer      Timer
+		cancel     chan struct{}
+		generation uint64
+		pending    bool
+		mux        sync.Mutex
+		runMux     sync.Mutex
+	}
+)
+
+// Debounce - runs fn once calls stop for wait. Without edges set only the trailing edge is used.
+func Debounce(fn func(), wait time.Duration, opts EdgeOptions) DelayedFunc {
+	if !opts.Leading && !opts.Trailing {
+		opts.Trailing = true
+	}
+	return &_delayedFunc{fn: fn, wait: wait, opts: opts, clock: clockOrSystem(opts.Clock)}
+}
+
+// Throttle - runs fn at most once per interval. Without edges set both edges are used.
+func Throttle(fn func(), interval time.Duration, opts EdgeOptions) DelayedFunc {
+	if !opts.Leading && !opts.Trailing {
+		opts.Leading, opts.Trailing = true, true
+	}
+	return &_delayedFunc{fn: fn, wait: interval, opts: opts, clock: clockOrSystem(opts.Clock), throttle: true}
+}
+
+func (d *_delayedFunc) Call() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	if d.timer != nil {
+		d.pending = d.opts.Trailing
+		if !d.throttle {
+			d.schedule()
+		}
+		return
+	}
+
+	d.schedule()
+	d.pending = !d.opts.Leading
+	if d.opts.Leading {
+		// dispatched like the trailing run, so the caller does not wait for a run in progress
+		go d.run()
+	}
+}
+
+func (d *_delayedFunc) Flush() {
+	d.mux.Lock()
+	pending := d.pending
+	d.stop()
+	d.mux.Unlock()
+
+	if pending {
+		d.run()
+	}
+}
+
+func (d *_delayedFunc) Cancel() {
+	d.mux.Lock()
+	d.stop()
+	d.mux.Unlock()
+}
+
+func (d *_delayedFunc) schedule() {
+	d.stopTimer()
+	d.generation++
+	generation := d.generation
+
+	timer, cancel := d.clock.NewTimer(d.wait), make(chan struct{})
+	d.timer, d.cancel = timer, cancel
+	go func() {
+		select {
+		case <-timer.C():
+			d.fire(generation)
+		case <-cancel:
+		}
+	}()
+}
+
+func (d *_delayedFunc) stopTimer() {
+	if d.timer != nil {
+		d.timer.Stop()
+		close(d.cancel)
+		d.timer, d.cancel = nil, nil
+	}
+}
+
+func (d *_delayedFunc) stop() {
+	d.stopTimer()
+	d.generation++
+	d.pending = false
+}
+
+func (d *_delayedFunc) fire(generation uint64) {
+	d.mux.Lock()
+	if generation != d.generation {
+		d.mux.Unlock()
+		return
+	}
+
+	pending := d.pending
+	d.stop()
+	if pending && d.throttle {
+		d.schedule()
+	}
+	d.mux.Unlock()
+
+	if pending {
+		d.run()
+	}
+}
+
+func (d *_delayedFunc) run() {
+	d.runMux.Lock()
+	defer d.runMux.Unlock()
+
+	if err := Recovery(d.fn); err != nil && d.opts.ErrFunc != nil {
+		d.opts.ErrFunc(err)
+	}
+}
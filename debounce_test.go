@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func waitRuns(t *testing.T, runs <-chan struct{}, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d runs, got %d", n, i)
+		}
+	}
+	select {
+	case <-runs:
+		t.Fatalf("expected %d runs, got more", n)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestUnit_Debounce(t *testing.T) {
+	clock := newFakeClock(false)
+	runs := make(chan struct{}, 10)
+	fn := do.Debounce(func() {
+		runs <- struct{}{}
+	}, 20*time.Millisecond, do.EdgeOptions{Clock: clock})
+
+	for i := 0; i < 5; i++ {
+		fn.Call()
+		clock.Advance(10 * time.Millisecond)
+	}
+	waitRuns(t, runs, 0)
+	clock.Advance(10 * time.Millisecond)
+	waitRuns(t, runs, 1)
+
+	fn.Call()
+	fn.Flush()
+	casecheck.Equal(t, 1, len(runs))
+	<-runs
+	fn.Flush()
+	casecheck.Equal(t, 0, len(runs))
+
+	fn.Call()
+	fn.Cancel()
+	clock.Advance(time.Second)
+	waitRuns(t, runs, 0)
+}
+
+func TestUnit_DebounceLeading(t *testing.T) {
+	clock := newFakeClock(false)
+	runs := make(chan struct{}, 10)
+	fn := do.Debounce(func() {
+		runs <- struct{}{}
+	}, 20*time.Millisecond, do.EdgeOptions{Leading: true, Clock: clock})
+
+	fn.Call()
+	waitRuns(t, runs, 1)
+	fn.Call()
+	fn.Call()
+	clock.Advance(20 * time.Millisecond)
+	waitRuns(t, runs, 0)
+
+	fn = do.Debounce(func() {
+		runs <- struct{}{}
+	}, 20*time.Millisecond, do.EdgeOptions{Leading: true, Trailing: true, Clock: clock})
+	fn.Call()
+	fn.Call()
+	waitRuns(t, runs, 1)
+	clock.Advance(20 * time.Millisecond)
+	waitRuns(t, runs, 1)
+}
+
+func TestUnit_DebounceLeadingNotBlocked(t *testing.T) {
+	clock := newFakeClock(false)
+	runs := make(chan struct{}, 10)
+	release := make(chan struct{})
+	fn := do.Debounce(func() {
+		runs <- struct{}{}
+		<-release
+	}, 20*time.Millisecond, do.EdgeOptions{Leading: true, Trailing: true, Clock: clock})
+
+	fn.Call()
+	<-runs
+	fn.Cancel()
+
+	done := make(chan struct{})
+	go func() {
+		fn.Call()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Call is blocked by the run in progress")
+	}
+
+	close(release)
+	waitRuns(t, runs, 1)
+}
+
+func TestUnit_Throttle(t *testing.T) {
+	clock := newFakeClock(false)
+	runs := make(chan struct{}, 10)
+	fn := do.Throttle(func() {
+		runs <- struct{}{}
+	}, 30*time.Millisecond, do.EdgeOptions{Clock: clock})
+
+	fn.Call()
+	waitRuns(t, runs, 1)
+	fn.Call()
+	fn.Call()
+	waitRuns(t, runs, 0)
+	clock.Advance(30 * time.Millisecond)
+	waitRuns(t, runs, 1)
+	clock.Advance(30 * time.Millisecond)
+	waitRuns(t, runs, 0)
+
+	fn = do.Throttle(func() {
+		runs <- struct{}{}
+	}, 30*time.Millisecond, do.EdgeOptions{Leading: true, Clock: clock})
+	fn.Call()
+	fn.Call()
+	clock.Advance(30 * time.Millisecond)
+	waitRuns(t, runs, 1)
+}
+
+func TestUnit_DebouncePanic(t *testing.T) {
+	errs := make(chan error, 1)
+	fn := do.Debounce(func() {
+		panic("boom")
+	}, time.Millisecond, do.EdgeOptions{ErrFunc: func(err error) {
+		errs <- err
+	}})
+	fn.Call()
+
+	var pe *do.PanicError
+	err := <-errs
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Contains(t, err.Error(), "panic=boom trace=./debounce_test.go:")
+}
@@ -0,0 +1,16 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+// FramePathIn - framePath over the given main module and dependency versions instead of the build info.
+func FramePathIn(mainModule string, deps map[string]string, function, file string) (string, string) {
+	mods := buildModules{list: []buildModule{{path: mainModule, main: true}}}
+	for p, version := range deps {
+		mods.list = append(mods.list, buildModule{path: p, version: version})
+	}
+	p, _ := mods.framePath(function, file, TrimModule)
+	return funcPackage(function), p
+}
@@ -0,0 +1,168 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const maxStackDepth = 64
+
+type (
+	Frame struct {
+		Function string
+		Package  string
+		File     string
+		Line     int
+		// Path - file path trimmed according to SetTrimMode.
+		Path string
+		// Stdlib - the frame belongs to the standard library or the runtime.
+		Stdlib bool
+	}
+
+	// FrameFilter - returns true for frames to keep.
+	FrameFilter func(f Frame) bool
+)
+
+// SkipFunc - drops frames whose function name contains one of names.
+func SkipFunc(names ...string) FrameFilter {
+	return func(f Frame) bool {
+		return !skipFrame(f.Function, names)
+	}
+}
+
+// Frames - returns up to count frames of the call stack starting from the caller of Frames,
+// skip frames above it. Zero count means the default depth of 64 frames.
+func Frames(skip, count int, filters ...FrameFilter) []Frame {
+	if count <= 0 {
+		count = maxStackDepth
+	}
+	// runtime.Callers may spend slots on inlining markers, so more pcs are taken than needed
+	pcs := make([]uintptr, max(count+2, maxStackDepth))
+	n := runtime.Callers(skip+2, pcs)
+
+	frames := framesFrom(pcs[:n], filters...)
+	if len(frames) > count {
+		frames = frames[:count]
+	}
+	return frames
+}
+
+func framesFrom(pcs []uintptr, filters ...FrameFilter) []Frame {
+	frames := runtime.CallersFrames(pcs)
+	result := make([]Frame, 0, len(pcs))
+
+	for {
+		v, ok := frames.Next()
+		if !ok {
+			break
+		}
+
+		f := newFrame(v)
+		keep := true
+		for _, filter := range filters {
+			if !filter(f) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func newFrame(v runtime.Frame) Frame {
+	p, stdlib := framePath(v.Function, v.File)
+	return Frame{
+		Function: v.Function,
+		Package:  funcPackage(v.Function),
+		File:     v.File,
+		Line:     v.Line,
+		Path:     p,
+		Stdlib:   stdlib,
+	}
+}
+
+func funcPackage(function string) string {
+	// type parameters of generic functions may contain import paths
+	if i := strings.IndexByte(function, '['); i >= 0 {
+		function = function[:i]
+	}
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		function = function[:slash+1+dot]
+	}
+	// symbol names escape dots and other special characters of the last import path element,
+	// e.g. gopkg.in/yaml.v3 becomes gopkg.in/yaml%2ev3
+	return unescapeSymbolPath(function)
+}
+
+func unescapeSymbolPath(pkg string) string {
+	if !strings.Contains(pkg, "%") {
+		return pkg
+	}
+	var b strings.Builder
+	b.Grow(len(pkg))
+	for i := 0; i < len(pkg); i++ {
+		if pkg[i] == '%' && i+2 < len(pkg) {
+			if c, err := strconv.ParseUint(pkg[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(pkg[i])
+	}
+	return b.String()
+}
+
+func skipFrame(function string, skipFunc []string) bool {
+	for _, s := range skipFunc {
+		if strings.Contains(function, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Frame) String() string {
+	return f.Path + ":" + strconv.Itoa(f.Line) + " " + f.Function
+}
+
+func (f Frame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Function string `json:"function"`
+		Package  string `json:"package"`
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+		Path     string `json:"path"`
+		Stdlib   bool   `json:"stdlib"`
+	}{
+		Function: f.Function,
+		Package:  f.Package,
+		File:     f.File,
+		Line:     f.Line,
+		Path:     f.Path,
+		Stdlib:   f.Stdlib,
+	})
+}
+
+func (f Frame) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("function", f.Function),
+		slog.String("package", f.Package),
+		slog.String("file", f.File),
+		slog.Int("line", f.Line),
+		slog.String("path", f.Path),
+		slog.Bool("stdlib", f.Stdlib),
+	)
+}
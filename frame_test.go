@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_Frames(t *testing.T) {
+	frames := func() []do.Frame {
+		return do.Frames(0, 2)
+	}()
+	casecheck.Equal(t, 2, len(frames))
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Frames.func1", frames[0].Function)
+	casecheck.Equal(t, "go.osspkg.com/do_test", frames[0].Package)
+	casecheck.Equal(t, "./frame_test.go", frames[0].Path)
+	casecheck.True(t, strings.HasSuffix(frames[0].File, "/frame_test.go"))
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Frames", frames[1].Function)
+	casecheck.Contains(t, frames[0].String(), "./frame_test.go:")
+	casecheck.Contains(t, frames[0].String(), " go.osspkg.com/do_test.TestUnit_Frames.func1")
+
+	frames = func() []do.Frame {
+		return do.Frames(1, 0, do.SkipFunc("testing."))
+	}()
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Frames", frames[0].Function)
+	for _, f := range frames {
+		casecheck.False(t, strings.HasPrefix(f.Function, "testing."))
+	}
+
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Frames", strings.Fields(do.Trace(2, 1))[1])
+}
+
+func TestUnit_FramesTrimMode(t *testing.T) {
+	defer do.SetTrimMode(do.TrimModule)
+
+	frames := do.Frames(0, 0)
+	casecheck.Equal(t, "./frame_test.go", frames[0].Path)
+	casecheck.False(t, frames[0].Stdlib)
+	casecheck.Equal(t, "testing/testing.go", frames[1].Path)
+	casecheck.True(t, frames[1].Stdlib)
+
+	do.SetTrimMode(do.TrimPackage)
+	frames = do.Frames(0, 0)
+	casecheck.Equal(t, "go.osspkg.com/do/frame_test.go", frames[0].Path)
+	casecheck.Equal(t, "testing/testing.go", frames[1].Path)
+
+	do.SetTrimMode(do.TrimFull)
+	frames = do.Frames(0, 0)
+	casecheck.Equal(t, frames[0].File, frames[0].Path)
+	casecheck.Equal(t, frames[1].File, frames[1].Path)
+	casecheck.True(t, frames[1].Stdlib)
+}
+
+func TestUnit_FramesEscapedPackage(t *testing.T) {
+	deps := map[string]string{"example.com/dot.v2": "v2.1.0", "gopkg.in/yaml.v3": "v3.0.1"}
+
+	pkg, p := do.FramePathIn("go.osspkg.com/do", deps, "example.com/dot%2ev2.Get", "/tmp/build/dotv2/dot.go")
+	casecheck.Equal(t, "example.com/dot.v2", pkg)
+	casecheck.Equal(t, "example.com/dot.v2@v2.1.0/dot.go", p)
+
+	pkg, p = do.FramePathIn("go.osspkg.com/do", deps,
+		"gopkg.in/yaml%2ev3.(*decoder).unmarshal[go.shape.int]", "/root/go/pkg/mod/gopkg.in/yaml.v3@v3.0.1/decode.go")
+	casecheck.Equal(t, "gopkg.in/yaml.v3", pkg)
+	casecheck.Equal(t, "gopkg.in/yaml.v3@v3.0.1/decode.go", p)
+
+	pkg, p = do.FramePathIn("go.osspkg.com/do", deps, "go.osspkg.com/do_test.TestX", "/src/do/x_test.go")
+	casecheck.Equal(t, "go.osspkg.com/do_test", pkg)
+	casecheck.Equal(t, "./x_test.go", p)
+}
+
+func TestUnit_FrameEncoding(t *testing.T) {
+	f := do.Frame{
+		Function: "example.com/pkg.(*T).Run",
+		Package:  "example.com/pkg",
+		File:     "/src/pkg/t.go",
+		Line:     10,
+		Path:     "./pkg/t.go",
+	}
+
+	b, err := json.Marshal([]do.Frame{f})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t,
+		`[{"function":"example.com/pkg.(*T).Run","package":"example.com/pkg","file":"/src/pkg/t.go","line":10,"path":"./pkg/t.go","stdlib":false}]`,
+		string(b))
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})).Info("msg", "frame", f)
+	casecheck.Equal(t,
+		"level=INFO msg=msg frame.function=example.com/pkg.(*T).Run frame.package=example.com/pkg "+
+			"frame.file=/src/pkg/t.go frame.line=10 frame.path=./pkg/t.go frame.stdlib=false\n",
+		buf.String())
+}
@@ -0,0 +1,143 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrFutureNotReady = errors.New("future is not ready")
+	ErrNoFutures      = errors.New("no futures")
+)
+
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Go - runs call in a goroutine via Async and returns a future of its result.
+// A panic inside call settles the future with the same error that Async passes to errFunc.
+func Go[T any](ctx context.Context, call func(ctx context.Context) (T, error)) *Future[T] {
+	f := newFuture[T]()
+	Async(func() {
+		value, err := call(ctx)
+		f.settle(value, err)
+	}, func(err error) {
+		var zero T
+		f.settle(zero, err)
+	})
+	return f
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+func (f *Future[T]) settle(value T, err error) {
+	f.value, f.err = value, err
+	close(f.done)
+}
+
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await - waits for the result or for ctx to be done.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Result - returns the result without waiting, ErrFutureNotReady if the future is not settled yet.
+func (f *Future[T]) Result() (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+		var zero T
+		return zero, ErrFutureNotReady
+	}
+}
+
+// All - settles with all values in input order, or with the first error wrapped in TaskError.
+func All[T any](futures ...*Future[T]) *Future[[]T] {
+	f := newFuture[[]T]()
+	go func() {
+		settled := settleOrder(futures)
+		for range futures {
+			i := <-settled
+			if err := futures[i].err; err != nil {
+				f.settle(nil, &TaskError{Index: i, Err: err})
+				return
+			}
+		}
+		values := make([]T, len(futures))
+		for i, ft := range futures {
+			values[i] = ft.value
+		}
+		f.settle(values, nil)
+	}()
+	return f
+}
+
+// Any - settles with the first successful value, or with all errors joined if every future failed.
+func Any[T any](futures ...*Future[T]) *Future[T] {
+	f := newFuture[T]()
+	go func() {
+		var zero T
+		if len(futures) == 0 {
+			f.settle(zero, ErrNoFutures)
+			return
+		}
+		settled := settleOrder(futures)
+		errs := make([]error, 0, len(futures))
+		for range futures {
+			i := <-settled
+			if err := futures[i].err; err != nil {
+				errs = append(errs, &TaskError{Index: i, Err: err})
+				continue
+			}
+			f.settle(futures[i].value, nil)
+			return
+		}
+		f.settle(zero, JoinTaskErrors(errs))
+	}()
+	return f
+}
+
+// Race - settles with the result of the first settled future.
+func Race[T any](futures ...*Future[T]) *Future[T] {
+	f := newFuture[T]()
+	go func() {
+		if len(futures) == 0 {
+			var zero T
+			f.settle(zero, ErrNoFutures)
+			return
+		}
+		ft := futures[<-settleOrder(futures)]
+		f.settle(ft.value, ft.err)
+	}()
+	return f
+}
+
+func settleOrder[T any](futures []*Future[T]) <-chan int {
+	settled := make(chan int, len(futures))
+	for i, ft := range futures {
+		go func() {
+			<-ft.done
+			settled <- i
+		}()
+	}
+	return settled
+}
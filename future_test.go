@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func delayed[T any](value T, err error, delay time.Duration) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		time.Sleep(delay)
+		return value, err
+	}
+}
+
+func TestUnit_Go(t *testing.T) {
+	f := do.Go(context.TODO(), delayed(1, nil, 20*time.Millisecond))
+
+	_, err := f.Result()
+	casecheck.True(t, errors.Is(err, do.ErrFutureNotReady))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond)
+	defer cancel()
+	_, err = f.Await(ctx)
+	casecheck.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	<-f.Done()
+	v, err := f.Result()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 1, v)
+
+	f = do.Go(context.TODO(), func(ctx context.Context) (int, error) {
+		func() {
+			panic(1)
+		}()
+		return 0, nil
+	})
+	_, err = f.Await(context.TODO())
+	casecheck.Error(t, err)
+	casecheck.Contains(t, err.Error(), "panic=1 trace=./future_test.go:")
+	casecheck.Contains(t, err.Error(), "go.osspkg.com/do_test.TestUnit_Go.func1.1")
+}
+
+func TestUnit_All(t *testing.T) {
+	v, err := do.All(
+		do.Go(context.TODO(), delayed(1, nil, 10*time.Millisecond)),
+		do.Go(context.TODO(), delayed(2, nil, 0)),
+	).Await(context.TODO())
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []int{1, 2}, v)
+
+	_, err = do.All(
+		do.Go(context.TODO(), delayed(1, nil, 0)),
+		do.Go(context.TODO(), delayed(2, fmt.Errorf("fail"), 0)),
+	).Await(context.TODO())
+	casecheck.Error(t, err)
+	casecheck.Equal(t, "task #1: fail", err.Error())
+
+	v, err = do.All[int]().Await(context.TODO())
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []int{}, v)
+}
+
+func TestUnit_Any(t *testing.T) {
+	v, err := do.Any(
+		do.Go(context.TODO(), delayed(1, fmt.Errorf("fail"), 0)),
+		do.Go(context.TODO(), delayed(2, nil, 10*time.Millisecond)),
+	).Await(context.TODO())
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 2, v)
+
+	_, err = do.Any(
+		do.Go(context.TODO(), delayed(1, fmt.Errorf("fail1"), 0)),
+		do.Go(context.TODO(), delayed(2, fmt.Errorf("fail2"), 0)),
+	).Await(context.TODO())
+	casecheck.Error(t, err)
+	casecheck.Equal(t, "task #0: fail1\ntask #1: fail2", err.Error())
+
+	_, err = do.Any[int]().Await(context.TODO())
+	casecheck.True(t, errors.Is(err, do.ErrNoFutures))
+}
+
+func TestUnit_Race(t *testing.T) {
+	_, err := do.Race(
+		do.Go(context.TODO(), delayed(1, fmt.Errorf("fail"), 0)),
+		do.Go(context.TODO(), delayed(2, nil, 50*time.Millisecond)),
+	).Await(context.TODO())
+	casecheck.Error(t, err)
+	casecheck.Equal(t, "fail", err.Error())
+
+	_, err = do.Race[int]().Await(context.TODO())
+	casecheck.True(t, errors.Is(err, do.ErrNoFutures))
+}
@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// Group - collapses concurrent calls with the same key into one execution.
+	Group[K comparable, V any] interface {
+		// Do - runs fn once for all concurrent callers of key and shares its result.
+		// fn gets the context of the first caller without its cancellation,
+		// a caller whose ctx is done leaves with ctx.Err() and the call goes on.
+		Do(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) (V, error)
+		// Forget - the next Do for key starts a new call instead of joining the running one.
+		Forget(key K)
+	}
+
+	_group[K comparable, V any] struct {
+		calls map[K]*_groupCall[V]
+		mux   sync.Mutex
+	}
+
+	_groupCall[V any] struct {
+		done  chan struct{}
+		value V
+		err   error
+	}
+)
+
+func NewGroup[K comparable, V any]() Group[K, V] {
+	return &_group[K, V]{
+		calls: make(map[K]*_groupCall[V]),
+	}
+}
+
+func (g *_group[K, V]) Do(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) (V, error) {
+	g.mux.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		call = &_groupCall[V]{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.exec(context.WithoutCancel(ctx), key, call, fn)
+	}
+	g.mux.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (g *_group[K, V]) exec(ctx context.Context, key K, call *_groupCall[V], fn func(ctx context.Context) (V, error)) {
+	defer func() {
+		g.mux.Lock()
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		g.mux.Unlock()
+		close(call.done)
+	}()
+
+	if err := Recovery(func() {
+		call.value, call.err = fn(ctx)
+	}); err != nil {
+		var zero V
+		call.value, call.err = zero, err
+	}
+}
+
+func (g *_group[K, V]) Forget(key K) {
+	g.mux.Lock()
+	delete(g.calls, key)
+	g.mux.Unlock()
+}
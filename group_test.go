@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_Group(t *testing.T) {
+	g := do.NewGroup[string, int]()
+	var calls int64
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	errs := make([]error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = g.Do(context.TODO(), "a", func(ctx context.Context) (int, error) {
+				atomic.AddInt64(&calls, 1)
+				<-release
+				return 42, nil
+			})
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	casecheck.Equal(t, int64(1), atomic.LoadInt64(&calls))
+	casecheck.Equal(t, []int{42, 42, 42, 42, 42}, results)
+	casecheck.Equal(t, make([]error, 5), errs)
+
+	v, err := g.Do(context.TODO(), "a", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 1, v)
+}
+
+func TestUnit_GroupCancelAndForget(t *testing.T) {
+	g := do.NewGroup[int, string]()
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	result := make(chan string, 1)
+	go func() {
+		v, _ := g.Do(context.TODO(), 1, func(ctx context.Context) (string, error) { //nolint:errcheck
+			close(started)
+			<-release
+			return "shared", ctx.Err()
+		})
+		result <- v
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err := g.Do(ctx, 1, nil)
+	casecheck.True(t, errors.Is(err, context.Canceled))
+
+	g.Forget(1)
+	v, err := g.Do(context.TODO(), 1, func(ctx context.Context) (string, error) {
+		return "new", nil
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, "new", v)
+
+	close(release)
+	casecheck.Equal(t, "shared", <-result)
+}
+
+func TestUnit_GroupPanic(t *testing.T) {
+	g := do.NewGroup[int, int]()
+	_, err := g.Do(context.TODO(), 1, func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, "boom", pe.Value)
+}
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"time"
+)
+
+// Hedge - starts fn and one more attempt every delay without a result, at most maxAttempts in total.
+// A failed attempt starts the next one at once. The first success wins, the others are canceled.
+// Returns the index of the winning attempt, or the errors of all attempts joined as TaskError.
+func Hedge[T any](ctx context.Context, delay time.Duration, maxAttempts int, fn func(ctx context.Context) (T, error)) (T, int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxAttempts = max(maxAttempts, 1)
+	futures := make([]*Future[T], 0, maxAttempts)
+	settled := make(chan int, maxAttempts)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	launch := func() {
+		i := len(futures)
+		f := Go(ctx, fn)
+		futures = append(futures, f)
+		go func() {
+			<-f.Done()
+			settled <- i
+		}()
+		timer.Reset(delay)
+	}
+	launch()
+
+	var (
+		zero    T
+		errs    = make([]error, 0, maxAttempts)
+		pending = 1
+	)
+	for {
+		var tick <-chan time.Time
+		if len(futures) < maxAttempts {
+			tick = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return zero, -1, ctx.Err()
+
+		case <-tick:
+			launch()
+			pending++
+
+		case i := <-settled:
+			pending--
+			value, err := futures[i].Result()
+			if err == nil {
+				return value, i, nil
+			}
+			errs = append(errs, &TaskError{Index: i, Err: err})
+
+			if len(futures) < maxAttempts {
+				launch()
+				pending++
+			} else if pending == 0 {
+				return zero, -1, JoinTaskErrors(errs)
+			}
+		}
+	}
+}
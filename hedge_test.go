@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_Hedge(t *testing.T) {
+	var attempts, canceled atomic.Int64
+	v, winner, err := do.Hedge(context.TODO(), 10*time.Millisecond, 3, func(ctx context.Context) (int, error) {
+		n := attempts.Add(1)
+		if n == 1 {
+			<-ctx.Done()
+			canceled.Add(1)
+			return 0, ctx.Err()
+		}
+		return int(n), nil
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 2, v)
+	casecheck.Equal(t, 1, winner)
+	casecheck.Equal(t, int64(2), attempts.Load())
+	for canceled.Load() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	v, winner, err = do.Hedge(context.TODO(), time.Hour, 3, func(ctx context.Context) (int, error) {
+		return 7, nil
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 7, v)
+	casecheck.Equal(t, 0, winner)
+}
+
+func TestUnit_HedgeErrors(t *testing.T) {
+	var attempts atomic.Int64
+	_, winner, err := do.Hedge(context.TODO(), time.Hour, 3, func(ctx context.Context) (int, error) {
+		if attempts.Add(1) == 2 {
+			panic("boom")
+		}
+		return 0, fmt.Errorf("fail")
+	})
+	casecheck.Equal(t, -1, winner)
+	casecheck.Equal(t, int64(3), attempts.Load())
+	casecheck.Contains(t, err.Error(), "task #0: fail\ntask #1: panic=boom trace=./hedge_test.go:")
+	casecheck.Contains(t, err.Error(), "task #2: fail")
+
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = do.Hedge(ctx, time.Hour, 2, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	casecheck.True(t, errors.Is(err, context.DeadlineExceeded))
+}
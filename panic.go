@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// PanicError - recovered panic with the stack of the panicking goroutine.
+type PanicError struct {
+	Value     any
+	Stack     []Frame
+	Goroutine string
+	err       error
+}
+
+// newPanicError - must be called from the deferred function that recovered the panic,
+// skipFunc[0] is the recovery point reported as the origin when its caller is not on the stack.
+// The panic is reported to the observers registered with OnPanic, except a *PanicError raised again.
+func newPanicError(value any, skipFunc ...string) *PanicError {
+	err, ok := value.(error)
+	if !ok {
+		err = fmt.Errorf("%+v", value)
+	}
+	stack, origin := panicStack(skipFunc...)
+	pe := &PanicError{
+		Value:     value,
+		Stack:     stack,
+		Goroutine: goroutineLabel(),
+		err:       err,
+	}
+	if _, ok = value.(*PanicError); !ok {
+		notifyPanic(PanicInfo{Value: value, Stack: stack, Origin: origin, Goroutine: pe.Goroutine})
+	}
+	return pe
+}
+
+func (e *PanicError) Error() string {
+	if len(e.Stack) == 0 {
+		return "panic=" + e.err.Error()
+	}
+	return fmt.Sprintf("panic=%s trace=%s", e.err.Error(), e.Stack[0].String())
+}
+
+func (e *PanicError) Unwrap() error {
+	return e.err
+}
+
+func (e *PanicError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, "panic="+e.err.Error()) //nolint:errcheck
+		if len(e.Goroutine) > 0 {
+			io.WriteString(s, "\n"+e.Goroutine+":") //nolint:errcheck
+		}
+		for _, f := range e.Stack {
+			io.WriteString(s, "\n"+f.String()) //nolint:errcheck
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error()) //nolint:errcheck
+	}
+}
+
+// panicStack - must be called from the deferred function that recovered the panic,
+// returns frames of the panicking code without runtime and skipFunc frames,
+// and the function that called the first skipFunc frame.
+func panicStack(skipFunc ...string) ([]Frame, string) {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	all := make([]runtime.Frame, 0, n)
+	start := 0
+	for {
+		v, ok := frames.Next()
+		if !ok {
+			break
+		}
+		all = append(all, v)
+		if v.Function == "runtime.gopanic" {
+			start = len(all)
+		}
+	}
+
+	all = all[start:]
+	for len(all) > 1 && strings.HasPrefix(all[0].Function, "runtime.") {
+		all = all[1:]
+	}
+
+	var origin string
+	if len(skipFunc) > 0 {
+		origin = skipFunc[0]
+	}
+
+	result := make([]Frame, 0, len(all))
+	skipped, found := false, false
+	for _, v := range all {
+		if skipFrame(v.Function, skipFunc) {
+			skipped = true
+			continue
+		}
+		if skipped && !found {
+			origin, found = v.Function, true
+		}
+		result = append(result, newFrame(v))
+	}
+	return result, origin
+}
+
+func goroutineLabel() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	if i := bytes.IndexByte(buf, '['); i > 0 {
+		buf = buf[:i]
+	}
+	return string(bytes.TrimSpace(buf))
+}
+
+func (e *PanicError) StackFrames() []Frame {
+	return e.Stack
+}
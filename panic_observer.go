@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// PanicInfo - panic recovered by any function of the package.
+type PanicInfo struct {
+	Value any
+	// Stack - frames of the panicking code, must not be modified by observers.
+	Stack []Frame
+	// Origin - function that recovered the panic, e.g. the caller of Recovery or go.osspkg.com/do.Async.
+	Origin    string
+	Goroutine string
+}
+
+type panicObserver struct {
+	call func(info PanicInfo)
+}
+
+var (
+	panicObservers    atomic.Pointer[[]*panicObserver]
+	panicObserversMux sync.Mutex
+)
+
+// OnPanic - registers an observer that gets every panic recovered by Recovery, Try, Async,
+// AsyncGroup, StepByStep, StateMachine and other functions of the package, even if the panic
+// is not passed to a callback. Observers are called synchronously in the recovering goroutine,
+// their own panics are dropped. The returned func unregisters the observer.
+func OnPanic(call func(info PanicInfo)) (unregister func()) {
+	if call == nil {
+		return func() {}
+	}
+
+	o := &panicObserver{call: call}
+	updatePanicObservers(func(list []*panicObserver) []*panicObserver {
+		return append(list, o)
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			updatePanicObservers(func(list []*panicObserver) []*panicObserver {
+				return slices.DeleteFunc(list, func(v *panicObserver) bool { return v == o })
+			})
+		})
+	}
+}
+
+// SlogPanicObserver - observer for OnPanic that writes panics to logger at the error level,
+// nil logger means slog.Default().
+func SlogPanicObserver(logger *slog.Logger) func(info PanicInfo) {
+	return func(info PanicInfo) {
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		l.LogAttrs(context.Background(), slog.LevelError, "recovered panic",
+			slog.String("panic", fmt.Sprintf("%+v", info.Value)),
+			slog.String("origin", info.Origin),
+			slog.String("goroutine", info.Goroutine),
+			slog.Any("stack", info.Stack),
+		)
+	}
+}
+
+func updatePanicObservers(call func(list []*panicObserver) []*panicObserver) {
+	panicObserversMux.Lock()
+	defer panicObserversMux.Unlock()
+
+	var list []*panicObserver
+	if v := panicObservers.Load(); v != nil {
+		list = slices.Clone(*v)
+	}
+	list = call(list)
+	panicObservers.Store(&list)
+}
+
+func notifyPanic(info PanicInfo) {
+	list := panicObservers.Load()
+	if list == nil {
+		return
+	}
+	for _, o := range *list {
+		func() {
+			defer func() {
+				recover() //nolint:errcheck
+			}()
+			o.call(info)
+		}()
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_OnPanic(t *testing.T) {
+	var (
+		mux   sync.Mutex
+		infos = make(map[string]do.PanicInfo)
+		wg    sync.WaitGroup
+	)
+	unregister := do.OnPanic(func(info do.PanicInfo) {
+		if s, ok := info.Value.(string); ok && strings.HasPrefix(s, "observer:") {
+			mux.Lock()
+			infos[s] = info
+			mux.Unlock()
+			if s == "observer:async" {
+				wg.Done()
+			}
+		}
+	})
+	defer do.OnPanic(func(do.PanicInfo) { panic("observer panic") })()
+
+	do.Try(func() {
+		panic("observer:try")
+	}, func(err error) {
+		panic("observer:catch")
+	}, func() {
+		panic("observer:finally")
+	})
+
+	wg.Add(1)
+	do.Async(func() {
+		panic("observer:async")
+	}, nil)
+	wg.Wait()
+
+	casecheck.Error(t, do.Recovery(func() {
+		panic("observer:recovery")
+	}))
+
+	sbs := do.NewStepByStep[int]()
+	sbs.Add(func(int) (int, error) { panic("observer:step") })
+	_, err := sbs.Exec(0)
+	casecheck.Error(t, err)
+
+	unregister()
+	unregister()
+	casecheck.Error(t, do.Recovery(func() {
+		panic("observer:unregistered")
+	}))
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	casecheck.Equal(t, 6, len(infos))
+	casecheck.Equal(t, "go.osspkg.com/do.Try", infos["observer:try"].Origin)
+	casecheck.Equal(t, "go.osspkg.com/do.Try", infos["observer:catch"].Origin)
+	casecheck.Equal(t, "go.osspkg.com/do.Try", infos["observer:finally"].Origin)
+	casecheck.Equal(t, "go.osspkg.com/do.Async", infos["observer:async"].Origin)
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_OnPanic", infos["observer:recovery"].Origin)
+	casecheck.Contains(t, infos["observer:step"].Origin, "go.osspkg.com/do.(*_stepByStep[...]).Exec")
+
+	info := infos["observer:recovery"]
+	casecheck.Contains(t, info.Goroutine, "goroutine ")
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_OnPanic.func", info.Stack[0].Function[:len(info.Stack[0].Function)-1])
+}
+
+func TestUnit_SlogPanicObserver(t *testing.T) {
+	var buf bytes.Buffer
+	observer := do.SlogPanicObserver(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	observer(do.PanicInfo{
+		Value:     fmt.Errorf("boom"),
+		Stack:     []do.Frame{{Function: "example.com/pkg.Run", Path: "./run.go", Line: 5}},
+		Origin:    "go.osspkg.com/do.Try",
+		Goroutine: "goroutine 7",
+	})
+
+	var rec struct {
+		Level     string `json:"level"`
+		Msg       string `json:"msg"`
+		Panic     string `json:"panic"`
+		Origin    string `json:"origin"`
+		Goroutine string `json:"goroutine"`
+		Stack     []struct {
+			Function string `json:"function"`
+			Line     int    `json:"line"`
+		} `json:"stack"`
+	}
+	casecheck.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	casecheck.Equal(t, "ERROR", rec.Level)
+	casecheck.Equal(t, "recovered panic", rec.Msg)
+	casecheck.Equal(t, "boom", rec.Panic)
+	casecheck.Equal(t, "go.osspkg.com/do.Try", rec.Origin)
+	casecheck.Equal(t, "goroutine 7", rec.Goroutine)
+	casecheck.Equal(t, 1, len(rec.Stack))
+	casecheck.Equal(t, "example.com/pkg.Run", rec.Stack[0].Function)
+	casecheck.Equal(t, 5, rec.Stack[0].Line)
+}
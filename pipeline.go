@@ -0,0 +1,223 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	PipelineUnordered PipelineMode = iota
+	PipelineOrdered
+)
+
+type (
+	PipelineMode int
+
+	Pipeline[V any] interface {
+		// Add - appends a stage served by workers goroutines with an output buffer of size buffer.
+		Add(workers, buffer int, fn func(ctx context.Context, value V) (V, error))
+		// Run - streams in through the stages. out must be read until it is closed,
+		// wait returns the first stage error or the ctx error after that.
+		Run(ctx context.Context, in <-chan V) (out <-chan V, wait func() error)
+		Exec(ctx context.Context, values []V) ([]V, error)
+	}
+
+	_pipeline[V any] struct {
+		mode   PipelineMode
+		stages []_pipelineStage[V]
+	}
+
+	_pipelineStage[V any] struct {
+		workers int
+		buffer  int
+		fn      func(ctx context.Context, value V) (V, error)
+	}
+
+	_pipelineItem[V any] struct {
+		seq   uint64
+		value V
+	}
+)
+
+func NewPipeline[V any](mode PipelineMode) Pipeline[V] {
+	return &_pipeline[V]{
+		mode:   mode,
+		stages: make([]_pipelineStage[V], 0),
+	}
+}
+
+func (p *_pipeline[V]) Add(workers, buffer int, fn func(ctx context.Context, value V) (V, error)) {
+	p.stages = append(p.stages, _pipelineStage[V]{
+		workers: max(workers, 1),
+		buffer:  max(buffer, 0),
+		fn:      fn,
+	})
+}
+
+func (p *_pipeline[V]) Exec(ctx context.Context, values []V) ([]V, error) {
+	// stops the FromSlice goroutine when a stage fails before all values are read
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out, wait := p.Run(ctx, FromSlice(ctx, values))
+	result := make([]V, 0, len(values))
+	for v := range out {
+		result = append(result, v)
+	}
+	if err := wait(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *_pipeline[V]) Run(ctx context.Context, in <-chan V) (<-chan V, func() error) {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+
+	var (
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	// every goroutine of the run, wait returns only after all of them exit
+	var wg sync.WaitGroup
+
+	// in ordered mode the source admits at most window items that were not emitted yet,
+	// so a slow item does not make the rest of the stream pile up in the reorder map
+	var slots chan struct{}
+	if p.mode == PipelineOrdered {
+		window := 1
+		for _, stage := range p.stages {
+			window += stage.workers + stage.buffer
+		}
+		slots = make(chan struct{}, window)
+	}
+
+	src := make(chan _pipelineItem[V])
+	wg.Add(1)
+	go func() {
+		defer func() {
+			close(src)
+			wg.Done()
+		}()
+		var seq uint64
+		for v := range OrDone(ctx, in) {
+			if slots != nil && !send(ctx, slots, struct{}{}) {
+				return
+			}
+			if !send(ctx, src, _pipelineItem[V]{seq: seq, value: v}) {
+				return
+			}
+			seq++
+		}
+	}()
+
+	var cur <-chan _pipelineItem[V] = src
+	for i, stage := range p.stages {
+		cur = p.runStage(ctx, i, stage, cur, fail, &wg)
+	}
+
+	out := make(chan V)
+	wg.Add(1)
+	go func() {
+		defer func() {
+			close(out)
+			cancel()
+			wg.Done()
+		}()
+
+		if p.mode != PipelineOrdered {
+			for it := range cur {
+				if !send(ctx, out, it.value) {
+					return
+				}
+			}
+			return
+		}
+
+		var next uint64
+		pending := make(map[uint64]V, cap(slots))
+		for it := range cur {
+			pending[it.seq] = it.value
+			for {
+				v, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				if !send(ctx, out, v) {
+					return
+				}
+				<-slots
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	return out, func() error {
+		<-done
+		if firstErr != nil {
+			return firstErr
+		}
+		return parent.Err()
+	}
+}
+
+func (p *_pipeline[V]) runStage(
+	ctx context.Context, index int, stage _pipelineStage[V],
+	in <-chan _pipelineItem[V], fail func(err error), all *sync.WaitGroup,
+) <-chan _pipelineItem[V] {
+	out := make(chan _pipelineItem[V], stage.buffer)
+
+	var wg sync.WaitGroup
+	wg.Add(stage.workers)
+	all.Add(stage.workers)
+	for i := 0; i < stage.workers; i++ {
+		go func() {
+			defer func() {
+				wg.Done()
+				all.Done()
+			}()
+			for it := range OrDone(ctx, in) {
+				var err error
+				if e := Recovery(func() {
+					it.value, err = stage.fn(ctx, it.value)
+				}); e != nil {
+					fail(fmt.Errorf("panic on stage #%d: %w", index+1, e))
+					return
+				}
+				if err != nil {
+					fail(fmt.Errorf("fail on stage #%d: %w", index+1, err))
+					return
+				}
+				if !send(ctx, out, it) {
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
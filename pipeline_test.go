@@ -0,0 +1,183 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_Pipeline(t *testing.T) {
+	values := make([]int, 50)
+	expected := make([]int, 50)
+	for i := range values {
+		values[i] = i
+		expected[i] = (i + 1) * 10
+	}
+
+	for _, mode := range []do.PipelineMode{do.PipelineOrdered, do.PipelineUnordered} {
+		p := do.NewPipeline[int](mode)
+		p.Add(4, 2, func(ctx context.Context, value int) (int, error) {
+			time.Sleep(time.Duration(value%3) * time.Millisecond)
+			return value + 1, nil
+		})
+		p.Add(2, 0, func(ctx context.Context, value int) (int, error) {
+			return value * 10, nil
+		})
+
+		out, err := p.Exec(context.TODO(), values)
+		casecheck.NoError(t, err)
+		if mode == do.PipelineUnordered {
+			sort.Ints(out)
+		}
+		casecheck.Equal(t, expected, out)
+	}
+}
+
+func TestUnit_PipelineError(t *testing.T) {
+	var processed int64
+	p := do.NewPipeline[int](do.PipelineOrdered)
+	p.Add(1, 0, func(ctx context.Context, value int) (int, error) {
+		atomic.AddInt64(&processed, 1)
+		return value, nil
+	})
+	p.Add(2, 0, func(ctx context.Context, value int) (int, error) {
+		if value == 3 {
+			return 0, fmt.Errorf("bad value")
+		}
+		return value, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < 1000; i++ {
+			select {
+			case in <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	out, wait := p.Run(ctx, in)
+	for range out {
+	}
+	err := wait()
+	casecheck.Error(t, err)
+	casecheck.Equal(t, "fail on stage #2: bad value", err.Error())
+	casecheck.True(t, atomic.LoadInt64(&processed) < 1000)
+
+	p = do.NewPipeline[int](do.PipelineUnordered)
+	p.Add(1, 0, func(ctx context.Context, value int) (int, error) {
+		panic("boom")
+	})
+	_, err = p.Exec(context.TODO(), []int{1})
+	casecheck.Contains(t, err.Error(), "panic on stage #1: panic=boom trace=./pipeline_test.go:")
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+}
+
+func TestUnit_PipelineExecLeak(t *testing.T) {
+	p := do.NewPipeline[int](do.PipelineOrdered)
+	p.Add(2, 0, func(ctx context.Context, value int) (int, error) {
+		return 0, fmt.Errorf("bad value")
+	})
+	values := make([]int, 100)
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		_, err := p.Exec(context.Background(), values)
+		casecheck.Error(t, err)
+	}
+
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	casecheck.True(t, runtime.NumGoroutine() <= before)
+}
+
+func TestUnit_PipelineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	p := do.NewPipeline[int](do.PipelineOrdered)
+	p.Add(1, 0, func(ctx context.Context, value int) (int, error) {
+		return value, nil
+	})
+
+	in := make(chan int)
+	out, wait := p.Run(ctx, in)
+	in <- 1
+	casecheck.Equal(t, 1, <-out)
+	cancel()
+	for range out {
+	}
+	casecheck.True(t, errors.Is(wait(), context.Canceled))
+}
+
+func TestUnit_PipelineWaitUpstream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	var returned atomic.Bool
+	p := do.NewPipeline[int](do.PipelineUnordered)
+	p.Add(1, 0, func(ctx context.Context, value int) (int, error) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		returned.Store(true)
+		return value, ctx.Err()
+	})
+	p.Add(1, 0, func(ctx context.Context, value int) (int, error) {
+		return value, nil
+	})
+
+	out, wait := p.Run(ctx, do.FromSlice(ctx, []int{1, 2, 3}))
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	for range out {
+	}
+	casecheck.True(t, errors.Is(wait(), context.Canceled))
+	casecheck.True(t, returned.Load())
+}
+
+func TestUnit_PipelineOrderedWindow(t *testing.T) {
+	release := make(chan struct{})
+	var started atomic.Int64
+	p := do.NewPipeline[int](do.PipelineOrdered)
+	p.Add(2, 1, func(ctx context.Context, value int) (int, error) {
+		started.Add(1)
+		if value == 0 {
+			<-release
+		}
+		return value, nil
+	})
+
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = i
+	}
+	result := make(chan []int, 1)
+	errs := make(chan error, 1)
+	go func() {
+		v, err := p.Exec(context.TODO(), values)
+		result <- v
+		errs <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	casecheck.True(t, started.Load() <= 4)
+	close(release)
+	casecheck.Equal(t, values, <-result)
+	casecheck.NoError(t, <-errs)
+}
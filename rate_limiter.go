@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrRateLimitBurst = errors.New("rate limiter burst exceeded")
+
+type (
+	// RateLimiterConfig - token bucket refilled with Rate tokens per second up to Burst tokens.
+	RateLimiterConfig struct {
+		Rate  float64
+		Burst int
+		Clock Clock
+	}
+
+	RateLimiter interface {
+		// Wait - waits for a token until ctx is done.
+		Wait(ctx context.Context) error
+		// Allow - takes a token if it is available right now.
+		Allow() bool
+		// Reserve - takes n tokens in advance and returns how long to wait before using them.
+		Reserve(n int) (time.Duration, error)
+	}
+
+	_rateLimiter struct {
+		rate   float64
+		burst  float64
+		clock  Clock
+		tokens float64
+		last   time.Time
+		mux    sync.Mutex
+	}
+)
+
+func NewRateLimiter(conf RateLimiterConfig) RateLimiter {
+	clock := clockOrSystem(conf.Clock)
+	burst := float64(max(conf.Burst, 1))
+	return &_rateLimiter{
+		rate:   conf.Rate,
+		burst:  burst,
+		clock:  clock,
+		tokens: burst,
+		last:   clock.Now(),
+	}
+}
+
+func (l *_rateLimiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+func (l *_rateLimiter) delay() time.Duration {
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *_rateLimiter) Allow() bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.advance(l.clock.Now())
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+func (l *_rateLimiter) Reserve(n int) (time.Duration, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if float64(n) > l.burst || n > 0 && l.rate <= 0 && l.tokens < float64(n) {
+		return 0, ErrRateLimitBurst
+	}
+
+	l.advance(l.clock.Now())
+	l.tokens -= float64(n)
+	return l.delay(), nil
+}
+
+func (l *_rateLimiter) cancel(n int) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.advance(l.clock.Now())
+	l.tokens = min(l.burst, l.tokens+float64(n))
+}
+
+func (l *_rateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d, err := l.Reserve(1)
+	if err != nil {
+		return err
+	}
+	if d == 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(l.clock.Now()) < d {
+		l.cancel(1)
+		return context.DeadlineExceeded
+	}
+
+	if err = sleep(ctx, l.clock, d); err != nil {
+		l.cancel(1)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_RateLimiter(t *testing.T) {
+	clock := newFakeClock(false)
+	rl := do.NewRateLimiter(do.RateLimiterConfig{Rate: 10, Burst: 2, Clock: clock})
+
+	casecheck.True(t, rl.Allow())
+	casecheck.True(t, rl.Allow())
+	casecheck.False(t, rl.Allow())
+
+	clock.Advance(100 * time.Millisecond)
+	casecheck.True(t, rl.Allow())
+	casecheck.False(t, rl.Allow())
+
+	d, err := rl.Reserve(2)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 200*time.Millisecond, d)
+
+	_, err = rl.Reserve(3)
+	casecheck.True(t, errors.Is(err, do.ErrRateLimitBurst))
+
+	clock.Advance(200 * time.Millisecond)
+	casecheck.False(t, rl.Allow())
+	clock.Advance(100 * time.Millisecond)
+	casecheck.True(t, rl.Allow())
+}
+
+func TestUnit_RateLimiterWait(t *testing.T) {
+	clock := newFakeClock(false)
+	rl := do.NewRateLimiter(do.RateLimiterConfig{Rate: 1, Burst: 1, Clock: clock})
+	casecheck.NoError(t, rl.Wait(context.TODO()))
+
+	done := make(chan error)
+	go func() {
+		done <- rl.Wait(context.TODO())
+	}()
+	clock.WaitTimers(1)
+	clock.Advance(time.Second)
+	casecheck.NoError(t, <-done)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		done <- rl.Wait(ctx)
+	}()
+	clock.WaitTimers(1)
+	cancel()
+	casecheck.True(t, errors.Is(<-done, context.Canceled))
+
+	clock.Advance(time.Second)
+	casecheck.True(t, rl.Allow())
+
+	ctx, cancel = context.WithTimeout(context.TODO(), time.Millisecond)
+	defer cancel()
+	casecheck.True(t, errors.Is(rl.Wait(ctx), context.DeadlineExceeded))
+}
+
+func TestUnit_AsyncGroupRateLimit(t *testing.T) {
+	rl := do.NewRateLimiter(do.RateLimiterConfig{Rate: 100, Burst: 1})
+	calls := make([]func(ctx context.Context) error, 0, 5)
+	for i := 0; i < 5; i++ {
+		calls = append(calls, func(ctx context.Context) error {
+			return nil
+		})
+	}
+
+	start := time.Now()
+	errs := do.AsyncGroupRateLimit(context.TODO(), rl, calls...)
+	casecheck.Equal(t, 0, len(errs))
+	casecheck.True(t, time.Since(start) >= 35*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 15*time.Millisecond)
+	defer cancel()
+	_, errs2 := do.AsyncMap(ctx, []int{1, 2, 3, 4, 5}, func(ctx context.Context, value int) (int, error) {
+		return value, nil
+	}, do.AsyncRateLimit(do.NewRateLimiter(do.RateLimiterConfig{Rate: 100, Burst: 1})))
+	casecheck.NoError(t, errs2[0])
+	casecheck.True(t, errors.Is(errs2[4], context.DeadlineExceeded))
+}
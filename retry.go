@@ -0,0 +1,172 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+type (
+	// Backoff - returns the delay before the next attempt, attempt starts from 1,
+	// prev is the previous delay.
+	Backoff func(attempt int, prev time.Duration) time.Duration
+
+	// RetryPolicy - zero MaxAttempts means DefaultRetryAttempts unless MaxElapsed is set, negative MaxAttempts
+	// and zero MaxElapsed mean no limit. Nil Backoff retries without delay, nil Retryable treats every error
+	// as retryable.
+	RetryPolicy struct {
+		Backoff     Backoff
+		MaxAttempts int
+		MaxElapsed  time.Duration
+		Retryable   func(err error) bool
+		Clock       Clock
+	}
+
+	// RetryError - keeps the errors of the last MaxRetryErrors attempts, Dropped is the number of earlier ones.
+	RetryError struct {
+		Attempts []error
+		Dropped  int
+	}
+)
+
+const (
+	// DefaultRetryAttempts - attempts limit of a RetryPolicy without MaxAttempts and MaxElapsed.
+	DefaultRetryAttempts = 10
+	// MaxRetryErrors - how many errors of the last attempts RetryError keeps.
+	MaxRetryErrors = 32
+)
+
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int, time.Duration) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff - base delay doubled on every attempt, base is at least 1ms and
+// zero or negative maxDelay means no limit.
+func ExponentialBackoff(base, maxDelay time.Duration) Backoff {
+	base, maxDelay = backoffLimits(base, maxDelay)
+	return func(attempt int, _ time.Duration) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			if delay > maxDelay/2 {
+				return maxDelay
+			}
+			delay *= 2
+		}
+		return min(delay, maxDelay)
+	}
+}
+
+// DecorrelatedJitterBackoff - random delay between base and three times the previous delay,
+// base is at least 1ms and zero or negative maxDelay means no limit.
+func DecorrelatedJitterBackoff(base, maxDelay time.Duration) Backoff {
+	base, maxDelay = backoffLimits(base, maxDelay)
+	return func(_ int, prev time.Duration) time.Duration {
+		upper := maxDelay
+		if prev <= maxDelay/3 {
+			upper = max(prev*3, base)
+		}
+		//nolint:gosec
+		return min(base+rand.N(upper-base+1), maxDelay)
+	}
+}
+
+const minBackoffDelay = time.Millisecond
+
+func backoffLimits(base, maxDelay time.Duration) (time.Duration, time.Duration) {
+	base = max(base, minBackoffDelay)
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64
+	}
+	return base, max(maxDelay, base)
+}
+
+func (e *RetryError) Error() string {
+	list := make([]string, 0, len(e.Attempts))
+	for i, err := range e.Attempts {
+		list = append(list, fmt.Sprintf("attempt #%d: %s", e.Dropped+i+1, err.Error()))
+	}
+	msg := strings.Join(list, "; ")
+	if e.Dropped > 0 {
+		msg = fmt.Sprintf("%d earlier attempts dropped; %s", e.Dropped, msg)
+	}
+	return msg
+}
+
+func (e *RetryError) Unwrap() []error {
+	return e.Attempts
+}
+
+// Last - error of the last attempt.
+func (e *RetryError) Last() error {
+	return e.Attempts[len(e.Attempts)-1]
+}
+
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	_, err := RetryValue(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+func RetryValue[T any](ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) (T, error)) (T, error) {
+	clock := clockOrSystem(policy.Clock)
+	start := clock.Now()
+
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts == 0 && policy.MaxElapsed <= 0 {
+		maxAttempts = DefaultRetryAttempts
+	}
+
+	var (
+		value T
+		delay time.Duration
+		rerr  RetryError
+	)
+
+	for attempt := 1; ; attempt++ {
+		var err error
+		if e := Recovery(func() {
+			value, err = fn(ctx)
+		}); e != nil {
+			err = e
+		}
+		if err == nil {
+			return value, nil
+		}
+
+		var zero T
+		if len(rerr.Attempts) == MaxRetryErrors {
+			rerr.Attempts = append(rerr.Attempts[:0], rerr.Attempts[1:]...)
+			rerr.Dropped++
+		}
+		rerr.Attempts = append(rerr.Attempts, err)
+
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return zero, &rerr
+		}
+		if maxAttempts > 0 && attempt >= maxAttempts {
+			return zero, &rerr
+		}
+
+		if policy.Backoff != nil {
+			delay = policy.Backoff(attempt, delay)
+		}
+		if policy.MaxElapsed > 0 && clock.Now().Add(delay).Sub(start) > policy.MaxElapsed {
+			return zero, &rerr
+		}
+
+		if err = sleep(ctx, clock, delay); err != nil {
+			return zero, fmt.Errorf("retry interrupted: %w: %w", err, &rerr)
+		}
+	}
+}
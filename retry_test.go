@@ -0,0 +1,174 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_Retry(t *testing.T) {
+	clock := newFakeClock(true)
+	attempt := 0
+	err := do.Retry(context.TODO(), do.RetryPolicy{
+		Backoff:     do.ExponentialBackoff(time.Second, 5*time.Second),
+		MaxAttempts: 5,
+		Clock:       clock,
+	}, func(ctx context.Context) error {
+		attempt++
+		if attempt == 2 {
+			panic("boom")
+		}
+		return fmt.Errorf("fail %d", attempt)
+	})
+	casecheck.Error(t, err)
+	casecheck.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}, clock.Delays())
+
+	var re *do.RetryError
+	casecheck.True(t, errors.As(err, &re))
+	casecheck.Equal(t, 5, len(re.Attempts))
+	casecheck.Equal(t, "fail 5", re.Last().Error())
+	casecheck.Contains(t, err.Error(), "attempt #1: fail 1; attempt #2: panic=boom trace=./retry_test.go:")
+
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, "boom", pe.Value)
+}
+
+func TestUnit_RetryValue(t *testing.T) {
+	clock := newFakeClock(true)
+	attempt := 0
+	v, err := do.RetryValue(context.TODO(), do.RetryPolicy{
+		Backoff: do.ConstantBackoff(time.Second),
+		Clock:   clock,
+	}, func(ctx context.Context) (int, error) {
+		attempt++
+		if attempt < 3 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return attempt, nil
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 3, v)
+	casecheck.Equal(t, []time.Duration{time.Second, time.Second}, clock.Delays())
+}
+
+func TestUnit_RetryLimits(t *testing.T) {
+	clock := newFakeClock(true)
+	attempt := 0
+	err := do.Retry(context.TODO(), do.RetryPolicy{
+		Backoff:    do.ConstantBackoff(time.Second),
+		MaxElapsed: 3500 * time.Millisecond,
+		Clock:      clock,
+	}, func(ctx context.Context) error {
+		attempt++
+		return io.EOF
+	})
+	casecheck.True(t, errors.Is(err, io.EOF))
+	casecheck.Equal(t, 4, attempt)
+
+	attempt = 0
+	err = do.Retry(context.TODO(), do.RetryPolicy{
+		Clock: clock,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, io.EOF)
+		},
+	}, func(ctx context.Context) error {
+		attempt++
+		return io.EOF
+	})
+	casecheck.True(t, errors.Is(err, io.EOF))
+	casecheck.Equal(t, 1, attempt)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	err = do.Retry(ctx, do.RetryPolicy{
+		Backoff: do.ConstantBackoff(time.Hour),
+		Clock:   newFakeClock(false),
+	}, func(ctx context.Context) error {
+		cancel()
+		return io.EOF
+	})
+	casecheck.True(t, errors.Is(err, context.Canceled))
+	casecheck.True(t, errors.Is(err, io.EOF))
+	casecheck.Equal(t, "retry interrupted: context canceled: attempt #1: EOF", err.Error())
+}
+
+func TestUnit_DecorrelatedJitterBackoff(t *testing.T) {
+	backoff := do.DecorrelatedJitterBackoff(time.Second, 10*time.Second)
+	var delay time.Duration
+	for i := 1; i < 100; i++ {
+		next := backoff(i, delay)
+		casecheck.True(t, next >= time.Second)
+		casecheck.True(t, next <= max(3*delay, time.Second))
+		casecheck.True(t, next <= 10*time.Second)
+		delay = next
+	}
+}
+
+func TestUnit_BackoffLimits(t *testing.T) {
+	exp := do.ExponentialBackoff(0, 0)
+	casecheck.Equal(t, time.Millisecond, exp(1, 0))
+	casecheck.Equal(t, 512*time.Millisecond, exp(10, 0))
+	casecheck.Equal(t, time.Duration(math.MaxInt64), exp(100, 0))
+
+	exp = do.ExponentialBackoff(time.Second, 5*time.Second)
+	casecheck.Equal(t, 4*time.Second, exp(3, 0))
+	casecheck.Equal(t, 5*time.Second, exp(4, 0))
+
+	jitter := do.DecorrelatedJitterBackoff(0, 0)
+	var delay time.Duration
+	for i := 1; i < 100; i++ {
+		delay = jitter(i, delay)
+		casecheck.True(t, delay >= time.Millisecond)
+	}
+	casecheck.True(t, jitter(1, math.MaxInt64) >= time.Millisecond)
+}
+
+func TestUnit_RetryDefaultLimits(t *testing.T) {
+	calls := 0
+	err := do.Retry(context.TODO(), do.RetryPolicy{}, func(ctx context.Context) error {
+		calls++
+		return io.EOF
+	})
+	casecheck.Equal(t, do.DefaultRetryAttempts, calls)
+	var re *do.RetryError
+	casecheck.True(t, errors.As(err, &re))
+	casecheck.Equal(t, do.DefaultRetryAttempts, len(re.Attempts))
+	casecheck.Equal(t, 0, re.Dropped)
+
+	calls = 0
+	err = do.Retry(context.TODO(), do.RetryPolicy{MaxAttempts: 40}, func(ctx context.Context) error {
+		calls++
+		return fmt.Errorf("fail %d", calls)
+	})
+	casecheck.Equal(t, 40, calls)
+	casecheck.True(t, errors.As(err, &re))
+	casecheck.Equal(t, do.MaxRetryErrors, len(re.Attempts))
+	casecheck.Equal(t, 8, re.Dropped)
+	casecheck.Equal(t, "fail 40", re.Last().Error())
+	casecheck.True(t, strings.HasPrefix(err.Error(), "8 earlier attempts dropped; attempt #9: fail 9; "))
+
+	calls = 0
+	err = do.Retry(context.TODO(), do.RetryPolicy{MaxAttempts: -1}, func(ctx context.Context) error {
+		calls++
+		if calls < 50 {
+			return io.EOF
+		}
+		return nil
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 50, calls)
+}
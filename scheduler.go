@@ -0,0 +1,190 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrSchedulerStopped = errors.New("scheduler is stopped")
+
+type (
+	Job struct {
+		Name     string
+		Schedule Schedule
+		Call     func(ctx context.Context) error
+		// Jitter - random delay up to Jitter added to every activation.
+		Jitter time.Duration
+		// SkipIfRunning - an activation is skipped while the previous run is not finished.
+		SkipIfRunning bool
+		// MaxDuration - timeout of the context passed to Call.
+		MaxDuration time.Duration
+		// ErrFunc - gets errors and recovered panics of Call.
+		ErrFunc func(err error)
+	}
+
+	Scheduler interface {
+		Add(job Job) error
+		Start()
+		// Stop - stops activations and waits for the running jobs until ctx is done.
+		Stop(ctx context.Context) error
+	}
+
+	_scheduler struct {
+		clock   Clock
+		jobs    []*_schedulerJob
+		started bool
+		stopped bool
+
+		ctx    context.Context
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+		mux    sync.Mutex
+	}
+
+	_schedulerJob struct {
+		Job
+		running atomic.Bool
+	}
+)
+
+func NewScheduler(clock Clock) Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &_scheduler{
+		clock:  clockOrSystem(clock),
+		jobs:   make([]*_schedulerJob, 0, 2),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (s *_scheduler) Add(job Job) error {
+	if job.Schedule == nil {
+		return errors.New("job must have a schedule")
+	}
+	if job.Call == nil {
+		return errors.New("job must have a call")
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.stopped {
+		return ErrSchedulerStopped
+	}
+
+	j := &_schedulerJob{Job: job}
+	s.jobs = append(s.jobs, j)
+	if s.started {
+		s.wg.Add(1)
+		go s.loop(j)
+	}
+	return nil
+}
+
+func (s *_scheduler) Start() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.started || s.stopped {
+		return
+	}
+	s.started = true
+
+	s.wg.Add(len(s.jobs))
+	for _, j := range s.jobs {
+		go s.loop(j)
+	}
+}
+
+func (s *_scheduler) Stop(ctx context.Context) error {
+	s.mux.Lock()
+	s.stopped = true
+	s.cancel()
+	s.mux.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *_scheduler) loop(j *_schedulerJob) {
+	defer s.wg.Done()
+
+	var last time.Time
+	for {
+		now := s.clock.Now()
+		next := j.Schedule.Next(now)
+		if !last.IsZero() {
+			// counted from the previous scheduled time, so the jitter of the previous run does not shift
+			// the schedule, missed runs are skipped
+			next = j.Schedule.Next(last)
+			for !next.IsZero() && next.Before(now) {
+				next = j.Schedule.Next(next)
+			}
+		}
+		if next.IsZero() {
+			return
+		}
+		last = next
+
+		delay := next.Sub(now)
+		if j.Jitter > 0 {
+			//nolint:gosec
+			delay += rand.N(j.Jitter)
+		}
+		if sleep(s.ctx, s.clock, delay) != nil {
+			return
+		}
+		s.run(j)
+	}
+}
+
+func (s *_scheduler) run(j *_schedulerJob) {
+	if j.SkipIfRunning && !j.running.CompareAndSwap(false, true) {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			if j.SkipIfRunning {
+				j.running.Store(false)
+			}
+			s.wg.Done()
+		}()
+
+		ctx, cancel := s.ctx, context.CancelFunc(func() {})
+		if j.MaxDuration > 0 {
+			ctx, cancel = context.WithTimeout(ctx, j.MaxDuration)
+		}
+		defer cancel()
+
+		var err error
+		if e := Recovery(func() {
+			err = j.Call(ctx)
+		}); e != nil {
+			err = e
+		}
+		if err != nil && j.ErrFunc != nil {
+			j.ErrFunc(err)
+		}
+	}()
+}
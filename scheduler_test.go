@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_Scheduler(t *testing.T) {
+	clock := newFakeClock(false)
+	s := do.NewScheduler(clock)
+
+	runs := make(chan time.Time, 10)
+	errs := make(chan error, 10)
+	var calls atomic.Int64
+	casecheck.NoError(t, s.Add(do.Job{
+		Name:     "every",
+		Schedule: do.Every(time.Minute),
+		Call: func(ctx context.Context) error {
+			runs <- clock.Now()
+			if calls.Add(1) == 2 {
+				panic("boom")
+			}
+			return fmt.Errorf("fail")
+		},
+		ErrFunc: func(err error) {
+			errs <- err
+		},
+	}))
+	casecheck.Error(t, s.Add(do.Job{Name: "bad"}))
+
+	s.Start()
+	for i := 1; i <= 2; i++ {
+		clock.WaitTimers(1)
+		clock.Advance(time.Minute)
+		casecheck.Equal(t, time.Date(2025, 1, 1, 0, i, 0, 0, time.UTC), <-runs)
+	}
+
+	casecheck.Equal(t, "fail", (<-errs).Error())
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(<-errs, &pe))
+
+	casecheck.NoError(t, s.Stop(context.TODO()))
+}
+
+func TestUnit_SchedulerSkipIfRunning(t *testing.T) {
+	clock := newFakeClock(false)
+	s := do.NewScheduler(clock)
+
+	started := make(chan bool, 10)
+	release := make(chan struct{})
+	casecheck.NoError(t, s.Add(do.Job{
+		Schedule:      do.Every(time.Second),
+		SkipIfRunning: true,
+		MaxDuration:   time.Hour,
+		Call: func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			started <- ok
+			<-release
+			return nil
+		},
+	}))
+	s.Start()
+
+	clock.WaitTimers(1)
+	clock.Advance(time.Second)
+	casecheck.True(t, <-started)
+	for i := 0; i < 3; i++ {
+		clock.WaitTimers(1)
+		clock.Advance(time.Second)
+	}
+	clock.WaitTimers(1)
+	casecheck.Equal(t, 0, len(started))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	casecheck.True(t, errors.Is(s.Stop(ctx), context.DeadlineExceeded))
+
+	close(release)
+	casecheck.NoError(t, s.Stop(context.TODO()))
+	casecheck.True(t, errors.Is(s.Add(do.Job{Schedule: do.Every(time.Second), Call: func(ctx context.Context) error {
+		return nil
+	}}), do.ErrSchedulerStopped))
+}
+
+func TestUnit_SchedulerJitterDrift(t *testing.T) {
+	clock := newFakeClock(true)
+	s := do.NewScheduler(clock)
+	casecheck.NoError(t, s.Add(do.Job{
+		Schedule: do.Every(time.Minute),
+		Jitter:   30 * time.Second,
+		Call:     func(ctx context.Context) error { return nil },
+	}))
+	s.Start()
+	for len(clock.Delays()) < 20 {
+		time.Sleep(time.Millisecond)
+	}
+	casecheck.NoError(t, s.Stop(context.TODO()))
+
+	var at time.Duration
+	for i, d := range clock.Delays()[:20] {
+		at += d
+		offset := at - time.Duration(i+1)*time.Minute
+		casecheck.True(t, offset >= 0 && offset < 30*time.Second)
+	}
+}
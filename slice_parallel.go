@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"runtime"
+)
+
+// Parallel helpers split in into workers contiguous chunks (GOMAXPROCS if workers <= 0),
+// stop at the first panic or ctx cancellation and return that error.
+
+func ParallelEach[T any](ctx context.Context, in []T, workers int, call func(value T, index int)) error {
+	return parallelChunks(ctx, len(in), workers, func(ctx context.Context, start, end int) error {
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			call(in[i], i)
+		}
+		return nil
+	})
+}
+
+func ParallelConvert[T, V any](ctx context.Context, in []T, workers int, call func(value T, index int) V) ([]V, error) {
+	out := make([]V, len(in))
+	err := ParallelEach(ctx, in, workers, func(value T, index int) {
+		out[index] = call(value, index)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func ParallelFilter[T any](ctx context.Context, in []T, workers int, filter func(value T, index int) bool) ([]T, error) {
+	keep, err := ParallelConvert(ctx, in, workers, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]T, 0, len(in))
+	for i, v := range in {
+		if keep[i] {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// ParallelReduce - reduces every chunk with call like Reduce does
+// and merges the chunk results in input order with the associative combine.
+func ParallelReduce[T any](
+	ctx context.Context, in []T, workers int,
+	call func(result, value T, index int) T, combine func(a, b T) T,
+) (out T, err error) {
+	step := parallelStep(len(in), parallelWorkers(len(in), workers))
+	results := make([]T, max((len(in)+step-1)/step, 1))
+
+	err = parallelChunks(ctx, len(in), workers, func(ctx context.Context, start, end int) error {
+		var result T
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			result = call(result, in[i], i)
+		}
+		results[start/step] = result
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	out = results[0]
+	for _, result := range results[1:] {
+		out = combine(out, result)
+	}
+	return
+}
+
+func parallelWorkers(size, workers int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return MinMax(workers, 1, max(size, 1))
+}
+
+func parallelStep(size, workers int) int {
+	return max((size+workers-1)/workers, 1)
+}
+
+func parallelChunks(ctx context.Context, size, workers int, call func(ctx context.Context, start, end int) error) error {
+	if size == 0 {
+		return ctx.Err()
+	}
+
+	step := parallelStep(size, parallelWorkers(size, workers))
+	callFuncs := make([]func(ctx context.Context) error, 0, size/step+1)
+	for start := 0; start < size; start += step {
+		end := min(start+step, size)
+		callFuncs = append(callFuncs, func(ctx context.Context) error {
+			return call(ctx, start, end)
+		})
+	}
+
+	if errs := asyncGroup(ctx, asyncGroupConfig{failFast: true}, callFuncs); len(errs) > 0 {
+		return errs[0].Err
+	}
+	return nil
+}
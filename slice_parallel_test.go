@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_ParallelEach(t *testing.T) {
+	var sum int64
+	err := do.ParallelEach(context.TODO(), do.Range(1, 100, 1), 4, func(value int, index int) {
+		atomic.AddInt64(&sum, int64(value))
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, int64(5050), sum)
+
+	casecheck.NoError(t, do.ParallelEach(context.TODO(), []int{}, 4, nil))
+}
+
+func TestUnit_ParallelConvert(t *testing.T) {
+	in := do.Range(0, 10, 1)
+	for _, workers := range []int{0, 1, 3, 6, 100} {
+		out, err := do.ParallelConvert(context.TODO(), in, workers, func(value int, index int) string {
+			return fmt.Sprintf("%d:%d", index, value)
+		})
+		casecheck.NoError(t, err)
+		casecheck.Equal(t, do.Convert(in, func(value int, index int) string {
+			return fmt.Sprintf("%d:%d", index, value)
+		}), out)
+	}
+
+	_, err := do.ParallelConvert(context.TODO(), in, 3, func(value int, index int) int {
+		if value == 7 {
+			panic("bad")
+		}
+		return value
+	})
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Contains(t, err.Error(), "panic=bad trace=./slice_parallel_test.go:")
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = do.ParallelConvert(ctx, in, 3, func(value int, index int) int { return value })
+	casecheck.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestUnit_ParallelFilter(t *testing.T) {
+	out, err := do.ParallelFilter(context.TODO(), do.Range(1, 20, 1), 3, func(value int, index int) bool {
+		return value%3 == 0
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []int{3, 6, 9, 12, 15, 18}, out)
+}
+
+func TestUnit_ParallelReduce(t *testing.T) {
+	in := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	for _, workers := range []int{1, 3, 4, 6, 20} {
+		out, err := do.ParallelReduce(context.TODO(), in, workers, func(result, value string, index int) string {
+			return result + value
+		}, func(a, b string) string {
+			return a + b
+		})
+		casecheck.NoError(t, err)
+		casecheck.Equal(t, "abcdefghij", out)
+	}
+
+	out, err := do.ParallelReduce(context.TODO(), []int{}, 4, nil, nil)
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 0, out)
+}
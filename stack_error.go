@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StackTracer - error that carries the frames of the place where it was created.
+type StackTracer interface {
+	StackFrames() []Frame
+}
+
+// StackError - error with the stack captured by WrapStack or Errorf.
+type StackError struct {
+	Err   error
+	Stack []Frame
+}
+
+// WrapStack - wraps err with the stack of the caller, nil and errors with a stack anywhere in the chain
+// are returned as is.
+func WrapStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	var st StackTracer
+	if errors.As(err, &st) {
+		return err
+	}
+	return &StackError{Err: err, Stack: Frames(1, 0)}
+}
+
+// Errorf - like fmt.Errorf, but the error carries the stack of the caller.
+func Errorf(format string, args ...any) error {
+	return &StackError{Err: fmt.Errorf(format, args...), Stack: Frames(1, 0)}
+}
+
+func (e *StackError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StackError) Unwrap() error {
+	return e.Err
+}
+
+func (e *StackError) StackFrames() []Frame {
+	return e.Stack
+}
+
+func (e *StackError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, e.Error()) //nolint:errcheck
+		for _, f := range e.Stack {
+			io.WriteString(s, "\n"+f.String()) //nolint:errcheck
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error()) //nolint:errcheck
+	}
+}
+
+// DeepestStack - returns the frames of the innermost StackTracer in the chain of err,
+// including errors joined with errors.Join, or nil if there is none.
+func DeepestStack(err error) []Frame {
+	frames, _ := deepestStack(err, 0)
+	return frames
+}
+
+func deepestStack(err error, depth int) ([]Frame, int) {
+	if err == nil {
+		return nil, -1
+	}
+
+	var (
+		result []Frame
+		found  = -1
+	)
+	// the chain is walked by hand to compare the depth of every stack
+	if st, ok := err.(StackTracer); ok { //nolint:errorlint
+		result, found = st.StackFrames(), depth
+	}
+
+	var children []error
+	switch v := err.(type) { //nolint:errorlint
+	case interface{ Unwrap() error }:
+		children = []error{v.Unwrap()}
+	case interface{ Unwrap() []error }:
+		children = v.Unwrap()
+	}
+	for _, child := range children {
+		if frames, d := deepestStack(child, depth+1); d > found {
+			result, found = frames, d
+		}
+	}
+	return result, found
+}
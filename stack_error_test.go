@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func stackErrorStep(v int) (int, error) {
+	return v, do.Errorf("step failed: %w", io.EOF)
+}
+
+func TestUnit_WrapStack(t *testing.T) {
+	casecheck.NoError(t, do.WrapStack(nil))
+
+	err := do.WrapStack(io.EOF)
+	casecheck.Equal(t, "EOF", err.Error())
+	casecheck.True(t, errors.Is(err, io.EOF))
+	casecheck.True(t, err == do.WrapStack(err))
+	wrapped := fmt.Errorf("ctx: %w", err)
+	casecheck.True(t, wrapped == do.WrapStack(wrapped))
+
+	var se *do.StackError
+	casecheck.True(t, errors.As(err, &se))
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_WrapStack", se.Stack[0].Function)
+
+	var st do.StackTracer
+	casecheck.True(t, errors.As(fmt.Errorf("wrap: %w", err), &st))
+	casecheck.Equal(t, "./stack_error_test.go", st.StackFrames()[0].Path)
+
+	out := fmt.Sprintf("%+v", err)
+	lines := strings.Split(out, "\n")
+	casecheck.Equal(t, "EOF", lines[0])
+	casecheck.Contains(t, lines[1], "./stack_error_test.go:")
+	casecheck.Contains(t, lines[1], " go.osspkg.com/do_test.TestUnit_WrapStack")
+	casecheck.Equal(t, "EOF", fmt.Sprintf("%v", err))
+	casecheck.Equal(t, `"EOF"`, fmt.Sprintf("%q", err))
+}
+
+func TestUnit_Errorf(t *testing.T) {
+	sbs := do.NewStepByStep[int]()
+	sbs.Add(func(v int) (int, error) { return v + 1, nil })
+	sbs.Add(stackErrorStep)
+
+	_, err := sbs.Exec(1)
+	casecheck.Error(t, err)
+	casecheck.Equal(t, "fail on step #2: step failed: EOF", err.Error())
+	casecheck.True(t, errors.Is(err, io.EOF))
+
+	frames := do.DeepestStack(err)
+	casecheck.Equal(t, "go.osspkg.com/do_test.stackErrorStep", frames[0].Function)
+}
+
+func TestUnit_DeepestStack(t *testing.T) {
+	casecheck.Equal(t, 0, len(do.DeepestStack(nil)))
+	casecheck.Equal(t, 0, len(do.DeepestStack(io.EOF)))
+
+	inner := stackErrorFrom("inner")
+	outer := &do.StackError{Err: fmt.Errorf("outer: %w", inner), Stack: []do.Frame{{Function: "outer"}}}
+	casecheck.Equal(t, "inner", do.DeepestStack(outer)[0].Function)
+
+	joined := errors.Join(io.EOF, fmt.Errorf("a: %w", stackErrorFrom("shallow")),
+		fmt.Errorf("b: %w", fmt.Errorf("c: %w", stackErrorFrom("deep"))))
+	casecheck.Equal(t, "deep", do.DeepestStack(joined)[0].Function)
+
+	perr := do.Recovery(func() {
+		panic("boom")
+	})
+	frames := do.DeepestStack(fmt.Errorf("wrap: %w", perr))
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_DeepestStack.func1", frames[0].Function)
+}
+
+func stackErrorFrom(function string) error {
+	return &do.StackError{Err: errors.New(function), Stack: []do.Frame{{Function: function}}}
+}
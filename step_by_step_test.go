@@ -6,6 +6,7 @@
 package do_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -46,4 +47,8 @@ func TestUnit_StepByStep(t *testing.T) {
 	casecheck.Error(t, err)
 	casecheck.Contains(t, err.Error(), "panic on step #3: panic=0 trace=./step_by_step_test.go")
 	casecheck.Contains(t, err.Error(), "go.osspkg.com/do_test.TestUnit_StepByStep.func3")
+
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, "0", pe.Value)
 }
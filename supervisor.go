@@ -0,0 +1,221 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// OneForOne - only the failed child is restarted.
+	OneForOne SupervisorStrategy = iota
+	// OneForAll - all children are stopped and restarted when one of them fails.
+	OneForAll
+)
+
+type (
+	SupervisorStrategy int
+
+	// SupervisorConfig - more than MaxRestarts restarts within Period stop the supervisor
+	// and call OnEscalate with the last error, zero MaxRestarts means no limit.
+	// Nil Backoff means ExponentialBackoff(100ms, 10s), so a child that fails at once is not
+	// restarted in a busy loop. Child errors and recovered panics are passed to ErrFunc.
+	SupervisorConfig struct {
+		Strategy    SupervisorStrategy
+		MaxRestarts int
+		Period      time.Duration
+		Backoff     Backoff
+		ErrFunc     func(err error)
+		OnEscalate  func(err error)
+		Clock       Clock
+	}
+
+	// Supervisor - runs children and restarts them when they fail,
+	// a child that returns nil is considered finished and is not restarted.
+	Supervisor interface {
+		Add(name string, call func(ctx context.Context) error) error
+		Start()
+		// Stop - cancels the children context and waits for them to exit until ctx is done.
+		Stop(ctx context.Context) error
+	}
+
+	_supervisor struct {
+		conf     SupervisorConfig
+		clock    Clock
+		children []_supervisorChild
+		restarts []time.Time
+		started  bool
+
+		ctx    context.Context
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+		mux    sync.Mutex
+	}
+
+	_supervisorChild struct {
+		name string
+		call func(ctx context.Context) error
+	}
+)
+
+func NewSupervisor(conf SupervisorConfig) Supervisor {
+	if conf.Backoff == nil {
+		conf.Backoff = ExponentialBackoff(100*time.Millisecond, 10*time.Second)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &_supervisor{
+		conf:     conf,
+		clock:    clockOrSystem(conf.Clock),
+		children: make([]_supervisorChild, 0, 2),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (s *_supervisor) Add(name string, call func(ctx context.Context) error) error {
+	if call == nil {
+		return errors.New("supervisor child must have a call")
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.started {
+		return errors.New("supervisor is already started")
+	}
+	s.children = append(s.children, _supervisorChild{name: name, call: call})
+	return nil
+}
+
+func (s *_supervisor) Start() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	if s.conf.Strategy == OneForAll {
+		s.wg.Add(1)
+		go s.supervise(s.children)
+		return
+	}
+
+	s.wg.Add(len(s.children))
+	for _, child := range s.children {
+		go s.supervise([]_supervisorChild{child})
+	}
+}
+
+func (s *_supervisor) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// supervise - runs the children together and restarts all of them when one fails.
+func (s *_supervisor) supervise(children []_supervisorChild) {
+	defer s.wg.Done()
+
+	var (
+		delay   time.Duration
+		attempt int
+	)
+	for {
+		start := s.clock.Now()
+		err := s.runChildren(children)
+		if err == nil || s.ctx.Err() != nil {
+			return
+		}
+
+		attempt++
+		if s.conf.Period > 0 && s.clock.Now().Sub(start) >= s.conf.Period {
+			attempt, delay = 1, 0
+		}
+
+		if s.conf.ErrFunc != nil {
+			s.conf.ErrFunc(err)
+		}
+		if !s.allowRestart() {
+			s.cancel()
+			if s.conf.OnEscalate != nil {
+				s.conf.OnEscalate(err)
+			}
+			return
+		}
+
+		delay = s.conf.Backoff(attempt, delay)
+		if sleep(s.ctx, s.clock, delay) != nil {
+			return
+		}
+	}
+}
+
+// runChildren - returns the first child failure, errors of children stopped after it are ignored.
+func (s *_supervisor) runChildren(children []_supervisorChild) error {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	errC := make(chan error, len(children))
+	for _, child := range children {
+		go func() {
+			var err error
+			if e := Recovery(func() {
+				err = child.call(ctx)
+			}); e != nil {
+				err = e
+			}
+			if err != nil {
+				err = fmt.Errorf("child %q: %w", child.name, err)
+			}
+			errC <- err
+		}()
+	}
+
+	var failure error
+	for range children {
+		if err := <-errC; err != nil && failure == nil && ctx.Err() == nil {
+			failure = err
+			cancel()
+		}
+	}
+	return failure
+}
+
+func (s *_supervisor) allowRestart() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.conf.MaxRestarts <= 0 {
+		return true
+	}
+
+	now := s.clock.Now()
+	if s.conf.Period > 0 {
+		s.restarts = Filter(s.restarts, func(value time.Time, _ int) bool {
+			return now.Sub(value) < s.conf.Period
+		})
+	}
+	s.restarts = append(s.restarts, now)
+	return len(s.restarts) <= s.conf.MaxRestarts
+}
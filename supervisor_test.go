@@ -0,0 +1,124 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_SupervisorOneForOne(t *testing.T) {
+	var flaky, stable atomic.Int64
+	errs := make(chan error, 10)
+	s := do.NewSupervisor(do.SupervisorConfig{
+		Strategy: do.OneForOne,
+		Backoff:  do.ConstantBackoff(time.Millisecond),
+		ErrFunc: func(err error) {
+			errs <- err
+		},
+	})
+	casecheck.NoError(t, s.Add("flaky", func(ctx context.Context) error {
+		switch flaky.Add(1) {
+		case 1:
+			panic("boom")
+		case 2:
+			return fmt.Errorf("fail")
+		}
+		<-ctx.Done()
+		return nil
+	}))
+	casecheck.NoError(t, s.Add("stable", func(ctx context.Context) error {
+		stable.Add(1)
+		<-ctx.Done()
+		return nil
+	}))
+	casecheck.Error(t, s.Add("bad", nil))
+
+	s.Start()
+	err := <-errs
+	casecheck.Contains(t, err.Error(), `child "flaky": panic=boom trace=./supervisor_test.go:`)
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, `child "flaky": fail`, (<-errs).Error())
+
+	for flaky.Load() != 3 {
+		time.Sleep(time.Millisecond)
+	}
+	casecheck.NoError(t, s.Stop(context.TODO()))
+	casecheck.Equal(t, int64(1), stable.Load())
+	casecheck.Error(t, s.Add("late", func(ctx context.Context) error { return nil }))
+}
+
+func TestUnit_SupervisorOneForAll(t *testing.T) {
+	var first, second atomic.Int64
+	s := do.NewSupervisor(do.SupervisorConfig{Strategy: do.OneForAll})
+	casecheck.NoError(t, s.Add("first", func(ctx context.Context) error {
+		if first.Add(1) == 1 {
+			return fmt.Errorf("fail")
+		}
+		<-ctx.Done()
+		return nil
+	}))
+	casecheck.NoError(t, s.Add("second", func(ctx context.Context) error {
+		second.Add(1)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	s.Start()
+	for first.Load() != 2 || second.Load() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	casecheck.NoError(t, s.Stop(context.TODO()))
+}
+
+func TestUnit_SupervisorEscalate(t *testing.T) {
+	var runs atomic.Int64
+	escalated := make(chan error, 1)
+	s := do.NewSupervisor(do.SupervisorConfig{
+		MaxRestarts: 2,
+		Period:      time.Minute,
+		OnEscalate: func(err error) {
+			escalated <- err
+		},
+	})
+	casecheck.NoError(t, s.Add("worker", func(ctx context.Context) error {
+		return fmt.Errorf("fail %d", runs.Add(1))
+	}))
+
+	s.Start()
+	casecheck.Equal(t, `child "worker": fail 3`, (<-escalated).Error())
+	casecheck.NoError(t, s.Stop(context.TODO()))
+	casecheck.Equal(t, int64(3), runs.Load())
+}
+
+func TestUnit_SupervisorDefaultBackoff(t *testing.T) {
+	clock := newFakeClock(false)
+	var fails atomic.Int64
+	s := do.NewSupervisor(do.SupervisorConfig{Clock: clock})
+	casecheck.NoError(t, s.Add("fail", func(ctx context.Context) error {
+		fails.Add(1)
+		return fmt.Errorf("fail")
+	}))
+	s.Start()
+
+	clock.WaitTimers(1)
+	casecheck.Equal(t, int64(1), fails.Load())
+	clock.Advance(100 * time.Millisecond)
+	clock.WaitTimers(1)
+	casecheck.Equal(t, int64(2), fails.Load())
+	casecheck.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, clock.Delays())
+
+	casecheck.NoError(t, s.Stop(context.TODO()))
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type (
+	TimeoutError struct {
+		Timeout time.Duration
+		// Elapsed - how long the call ran until it was abandoned or returned.
+		Elapsed time.Duration
+		// Err - error of the call that returned after the timeout, only with TimeoutWait.
+		Err error
+	}
+
+	TimeoutOption func(conf *timeoutConfig)
+
+	timeoutConfig struct {
+		wait bool
+	}
+
+	timeoutResult[T any] struct {
+		value T
+		err   error
+		panic *PanicError
+	}
+)
+
+func (e *TimeoutError) Error() string {
+	msg := fmt.Sprintf("call timed out after %s (timeout %s)", e.Elapsed, e.Timeout)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *TimeoutError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{context.DeadlineExceeded}
+	}
+	return []error{context.DeadlineExceeded, e.Err}
+}
+
+// TimeoutWait - after the timeout or cancellation of the parent context waits for the call to return
+// instead of abandoning its goroutine.
+func TimeoutWait() TimeoutOption {
+	return func(conf *timeoutConfig) {
+		conf.wait = true
+	}
+}
+
+// WithTimeout - runs fn with a context limited by timeout and returns TimeoutError if fn does not return in time.
+// A panic in fn is raised again in the calling goroutine as *PanicError.
+func WithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error), opts ...TimeoutOption) (T, error) {
+	r := callTimeout(ctx, timeout, fn, opts)
+	if r.panic != nil {
+		panic(r.panic)
+	}
+	return r.value, r.err
+}
+
+// Call - like WithTimeout, but a panic in fn is returned as *PanicError.
+func Call[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error), opts ...TimeoutOption) (T, error) {
+	r := callTimeout(ctx, timeout, fn, opts)
+	if r.panic != nil {
+		return r.value, r.panic
+	}
+	return r.value, r.err
+}
+
+func callTimeout[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error), opts []TimeoutOption) timeoutResult[T] {
+	conf := timeoutConfig{}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resC := make(chan timeoutResult[T], 1)
+	go func() {
+		var r timeoutResult[T]
+		if err := Recovery(func() {
+			r.value, r.err = fn(ctx)
+		}); err != nil {
+			errors.As(err, &r.panic)
+		}
+		resC <- r
+	}()
+
+	select {
+	case r := <-resC:
+		return r
+	case <-ctx.Done():
+	}
+
+	var r timeoutResult[T]
+	if conf.wait {
+		r = <-resC
+	}
+
+	// the parent context is done, so the timeout of this call is not the cause
+	if err := parent.Err(); err != nil {
+		result := timeoutResult[T]{err: err, panic: r.panic}
+		if r.err != nil {
+			result.err = fmt.Errorf("%w: %w", err, r.err)
+		}
+		return result
+	}
+
+	terr := &TimeoutError{Timeout: timeout, Err: r.err}
+	if r.panic != nil {
+		terr.Err = r.panic
+	}
+	terr.Elapsed = time.Since(start)
+	return timeoutResult[T]{err: terr}
+}
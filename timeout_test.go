@@ -0,0 +1,107 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_WithTimeout(t *testing.T) {
+	v, err := do.WithTimeout(context.TODO(), time.Second, func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 1, v)
+
+	_, err = do.WithTimeout(context.TODO(), 10*time.Millisecond, func(ctx context.Context) (int, error) {
+		time.Sleep(time.Second)
+		return 1, nil
+	})
+	var te *do.TimeoutError
+	casecheck.True(t, errors.As(err, &te))
+	casecheck.True(t, errors.Is(err, context.DeadlineExceeded))
+	casecheck.Equal(t, 10*time.Millisecond, te.Timeout)
+	casecheck.True(t, te.Elapsed >= 10*time.Millisecond && te.Elapsed < time.Second)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = do.WithTimeout(ctx, time.Second, func(ctx context.Context) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 0, nil
+	})
+	casecheck.True(t, errors.Is(err, context.Canceled))
+	casecheck.False(t, errors.As(err, &te))
+
+	var recovered any
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		_, _ = do.WithTimeout(context.TODO(), time.Second, func(ctx context.Context) (int, error) { //nolint:errcheck
+			panic("boom")
+		})
+	}()
+	pe, ok := recovered.(*do.PanicError)
+	casecheck.True(t, ok)
+	casecheck.Equal(t, "boom", pe.Value)
+}
+
+func TestUnit_CallTimeout(t *testing.T) {
+	_, err := do.Call(context.TODO(), time.Second, func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Contains(t, err.Error(), "panic=boom trace=./timeout_test.go:")
+
+	_, err = do.Call(context.TODO(), 10*time.Millisecond, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		return 0, fmt.Errorf("late: %w", ctx.Err())
+	}, do.TimeoutWait())
+	var te *do.TimeoutError
+	casecheck.True(t, errors.As(err, &te))
+	casecheck.True(t, te.Elapsed >= 30*time.Millisecond)
+	casecheck.Equal(t, "late: context deadline exceeded", te.Err.Error())
+	casecheck.Contains(t, err.Error(), "call timed out after ")
+}
+
+func TestUnit_CallParentDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	var finished atomic.Bool
+	_, err := do.Call(ctx, time.Second, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		finished.Store(true)
+		return 0, fmt.Errorf("late")
+	}, do.TimeoutWait())
+	casecheck.True(t, finished.Load())
+	casecheck.True(t, errors.Is(err, context.Canceled))
+	casecheck.Equal(t, "context canceled: late", err.Error())
+
+	ctx, cancel = context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err = do.Call(ctx, time.Second, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, nil
+	})
+	var te *do.TimeoutError
+	casecheck.False(t, errors.As(err, &te))
+	casecheck.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -7,26 +7,21 @@ package do
 
 import (
 	"bytes"
-	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"runtime/debug"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 func Recovery(call func()) (err error) {
 	defer func() {
 		if val := recover(); val != nil {
-			err = fmt.Errorf(
-				"panic=%w trace=%s",
-				fmt.Errorf("%+v", val),
-				Trace(
-					4,
-					1,
-					"go.osspkg.com/do.Recovery",
-				),
-			)
+			err = newPanicError(val, "go.osspkg.com/do.Recovery")
 		}
 	}()
 
@@ -36,18 +31,10 @@ func Recovery(call func()) (err error) {
 
 var bufPool = sync.Pool{New: func() any { return bytes.NewBuffer(make([]byte, 0, 1024)) }}
 
+// Trace - formats frames starting from the skipLines frame of runtime.Callers, one frame per line.
 func Trace(skipLines, countLines int, skipFunc ...string) string {
 	list := make([]uintptr, countLines+1)
-
-	//nolint:errcheck
-	execFile, _ := os.Executable()
-	execDir := filepath.Dir(execFile)
-	//nolint:errcheck
-	workDir, _ := os.Getwd()
-	goDir := os.Getenv("GOROOT")
-
 	n := runtime.Callers(skipLines, list)
-	frame := runtime.CallersFrames(list[:n])
 
 	//nolint:errcheck
 	buf := bufPool.Get().(*bytes.Buffer)
@@ -56,28 +43,160 @@ func Trace(skipLines, countLines int, skipFunc ...string) string {
 		bufPool.Put(buf)
 	}()
 
-	var line int
-	for {
-		v, ok := frame.Next()
-		if !ok {
-			break
+	for i, f := range framesFrom(list[:n], SkipFunc(skipFunc...)) {
+		if i > 0 {
+			buf.WriteString("\n")
 		}
+		buf.WriteString(f.String())
+	}
+	return buf.String()
+}
+
+// TrimMode - defines how Frame.Path is built from the source file of a frame.
+type TrimMode int32
+
+const (
+	// TrimModule - path relative to the module root: "./dir/file.go" for the main module,
+	// "module@version/dir/file.go" for dependencies and "pkg/file.go" for the standard library.
+	TrimModule TrimMode = iota
+	// TrimPackage - import path of the package followed by the file name.
+	TrimPackage
+	// TrimFull - absolute file path as recorded in the binary.
+	TrimFull
+)
 
-		doWrite := true
-		for _, s := range skipFunc {
-			if strings.Contains(v.Function, s) {
-				doWrite = false
+var trimMode atomic.Int32
+
+// SetTrimMode - sets how paths of all frames captured after the call are trimmed, TrimModule by default.
+func SetTrimMode(mode TrimMode) {
+	trimMode.Store(int32(mode))
+}
+
+type buildModule struct {
+	path    string
+	version string
+	main    bool
+}
+
+type buildModules struct {
+	mainPackage string
+	list        []buildModule
+}
+
+var (
+	loadBuildModules = sync.OnceValue(func() buildModules {
+		bi, ok := debug.ReadBuildInfo()
+		if !ok {
+			return buildModules{}
+		}
+		result := buildModules{
+			mainPackage: strings.TrimSuffix(bi.Path, ".test"),
+			list:        make([]buildModule, 0, len(bi.Deps)+1),
+		}
+		if bi.Main.Path != "" {
+			result.list = append(result.list, buildModule{path: bi.Main.Path, main: true})
+		}
+		for _, m := range bi.Deps {
+			version := m.Version
+			if m.Replace != nil && m.Replace.Version != "" {
+				version = m.Replace.Version
 			}
+			result.list = append(result.list, buildModule{path: m.Path, version: version})
 		}
+		return result
+	})
 
-		if doWrite {
-			line++
-			filePath := strings.TrimPrefix(v.File, workDir)
-			filePath = strings.TrimPrefix(filePath, execDir)
-			filePath = strings.TrimPrefix(filePath, goDir)
-			fmt.Fprintf(buf, "%s.%s:%d %v", IfElse(line == 1, "", "\n"), filePath, v.Line, v.Function)
+	// loadGoRootSrc - detects the GOROOT/src directory by the source file of the runtime package,
+	// it is empty for binaries built with -trimpath.
+	loadGoRootSrc = sync.OnceValue(func() string {
+		pc := reflect.ValueOf(runtime.Gosched).Pointer()
+		file, _ := runtime.FuncForPC(pc).FileLine(pc)
+		if i := strings.LastIndex(file, "/runtime/"); i >= 0 && strings.HasSuffix(file[:i], "/src") {
+			return file[:i+1]
 		}
+		return ""
+	})
+)
 
+func (v buildModules) find(pkg string) (buildModule, bool) {
+	var (
+		result buildModule
+		found  bool
+	)
+	for _, m := range v.list {
+		if pkg != m.path && !strings.HasPrefix(pkg, m.path+"/") {
+			continue
+		}
+		if !found || len(m.path) > len(result.path) {
+			result, found = m, true
+		}
 	}
-	return buf.String()
+	return result, found
+}
+
+// importPath - returns the import path of the package the function belongs to.
+func (v buildModules) importPath(function string) string {
+	pkg := funcPackage(function)
+	if pkg == "main" {
+		return v.mainPackage
+	}
+	return strings.TrimSuffix(pkg, "_test")
+}
+
+func (v buildModules) isStdlib(pkg, file string) bool {
+	if root := loadGoRootSrc(); root != "" {
+		return strings.HasPrefix(file, root)
+	}
+	first, _, _ := strings.Cut(pkg, "/")
+	if pkg == "" || strings.Contains(first, ".") {
+		return false
+	}
+	_, ok := v.find(pkg)
+	return !ok
+}
+
+func framePath(function, file string) (string, bool) {
+	return loadBuildModules().framePath(function, file, TrimMode(trimMode.Load()))
+}
+
+func (v buildModules) framePath(function, file string, mode TrimMode) (string, bool) {
+	pkg := v.importPath(function)
+	stdlib := v.isStdlib(pkg, file)
+	name := path.Base(file)
+
+	switch mode {
+	case TrimFull:
+		return file, stdlib
+	case TrimPackage:
+		if pkg != "" {
+			return pkg + "/" + name, stdlib
+		}
+	default:
+		if stdlib && pkg != "" {
+			return pkg + "/" + name, stdlib
+		}
+		if m, ok := v.find(pkg); ok {
+			dir := strings.TrimPrefix(pkg, m.path)
+			if m.main {
+				return "." + dir + "/" + name, stdlib
+			}
+			if m.version != "" && m.version != "(devel)" {
+				return m.path + "@" + m.version + dir + "/" + name, stdlib
+			}
+			return m.path + dir + "/" + name, stdlib
+		}
+	}
+	return "." + trimPath(file), stdlib
+}
+
+// trimPath - fallback for frames outside of known modules.
+func trimPath(file string) string {
+	//nolint:errcheck
+	execFile, _ := os.Executable()
+	execDir := filepath.Dir(execFile)
+	//nolint:errcheck
+	workDir, _ := os.Getwd()
+
+	file = strings.TrimPrefix(file, workDir)
+	return strings.TrimPrefix(file, execDir)
 }
@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var asyncTracker atomic.Pointer[Tracker]
+
+type (
+	TrackedTask struct {
+		Name    string
+		Started time.Time
+		// Stack - where the task was started.
+		Stack []Frame
+	}
+
+	Tracker interface {
+		// Async - like the package Async, but the goroutine is registered until it exits.
+		Async(name string, callFunc func(), errFunc func(err error))
+		// Wait - waits until all registered goroutines exit or ctx is done.
+		Wait(ctx context.Context) error
+		Active() int
+		// Snapshot - still running tasks ordered by start time.
+		Snapshot() []TrackedTask
+	}
+
+	_tracker struct {
+		tasks map[uint64]*TrackedTask
+		seq   uint64
+		idle  chan struct{}
+		mux   sync.Mutex
+	}
+)
+
+// SetAsyncTracker - registers every goroutine started by Async in t, nil disables tracking.
+func SetAsyncTracker(t Tracker) {
+	if t == nil {
+		asyncTracker.Store(nil)
+		return
+	}
+	asyncTracker.Store(&t)
+}
+
+func NewTracker() Tracker {
+	idle := make(chan struct{})
+	close(idle)
+	return &_tracker{
+		tasks: make(map[uint64]*TrackedTask),
+		idle:  idle,
+	}
+}
+
+func (t *_tracker) Async(name string, callFunc func(), errFunc func(err error)) {
+	task := &TrackedTask{
+		Name:    name,
+		Started: time.Now(),
+		Stack:   Frames(1, 0, SkipFunc("go.osspkg.com/do.Async")),
+	}
+
+	t.mux.Lock()
+	t.seq++
+	id := t.seq
+	if len(t.tasks) == 0 {
+		t.idle = make(chan struct{})
+	}
+	t.tasks[id] = task
+	t.mux.Unlock()
+
+	async(callFunc, errFunc, func() {
+		t.mux.Lock()
+		delete(t.tasks, id)
+		if len(t.tasks) == 0 {
+			close(t.idle)
+		}
+		t.mux.Unlock()
+	})
+}
+
+func (t *_tracker) Wait(ctx context.Context) error {
+	t.mux.Lock()
+	idle := t.idle
+	t.mux.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *_tracker) Active() int {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	return len(t.tasks)
+}
+
+func (t *_tracker) Snapshot() []TrackedTask {
+	t.mux.Lock()
+	ids := make([]uint64, 0, len(t.tasks))
+	for id := range t.tasks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	result := make([]TrackedTask, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *t.tasks[id])
+	}
+	t.mux.Unlock()
+
+	return result
+}
@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_Tracker(t *testing.T) {
+	tr := do.NewTracker()
+	casecheck.NoError(t, tr.Wait(context.TODO()))
+
+	release := make(chan struct{})
+	errs := make(chan error, 1)
+	tr.Async("first", func() {
+		<-release
+	}, nil)
+	tr.Async("second", func() {
+		<-release
+		panic("boom")
+	}, func(err error) {
+		errs <- err
+	})
+
+	casecheck.Equal(t, 2, tr.Active())
+	snapshot := tr.Snapshot()
+	casecheck.Equal(t, 2, len(snapshot))
+	casecheck.Equal(t, "first", snapshot[0].Name)
+	casecheck.Equal(t, "second", snapshot[1].Name)
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_Tracker", snapshot[0].Stack[0].Function)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	casecheck.True(t, errors.Is(tr.Wait(ctx), context.DeadlineExceeded))
+
+	close(release)
+	casecheck.NoError(t, tr.Wait(context.TODO()))
+	casecheck.Equal(t, 0, tr.Active())
+	casecheck.Equal(t, 0, len(tr.Snapshot()))
+
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(<-errs, &pe))
+}
+
+func TestUnit_SetAsyncTracker(t *testing.T) {
+	tr := do.NewTracker()
+	do.SetAsyncTracker(tr)
+	defer do.SetAsyncTracker(nil)
+
+	release := make(chan struct{})
+	do.Async(func() {
+		<-release
+	}, nil)
+
+	snapshot := tr.Snapshot()
+	casecheck.Equal(t, 1, len(snapshot))
+	casecheck.Equal(t, "", snapshot[0].Name)
+	casecheck.Equal(t, "go.osspkg.com/do_test.TestUnit_SetAsyncTracker", snapshot[0].Stack[0].Function)
+
+	close(release)
+	casecheck.NoError(t, tr.Wait(context.TODO()))
+}
@@ -5,6 +5,13 @@
 
 package do
 
+import (
+	"errors"
+	"reflect"
+)
+
+// Try - runs try and passes its recovered panic to catch, finally is always called.
+// Panics in catch and finally are reported only to the observers of OnPanic, use TryE to get them.
 func Try(try func(), catch func(err error), finally func()) {
 	if try != nil {
 		err := Recovery(try)
@@ -21,3 +28,131 @@ func Try(try func(), catch func(err error), finally func()) {
 		_ = Recovery(finally)
 	}
 }
+
+type (
+	// CatchClause - handles the recovered panic value and returns true if it matches the clause.
+	CatchClause func(value any, err *PanicError) bool
+
+	TryBuilder interface {
+		// Catch - adds a clause, clauses are checked in the order they were added.
+		Catch(clause CatchClause) TryBuilder
+		// CatchValue - adds a clause that handles any panic with its raw value.
+		CatchValue(handler func(value any)) TryBuilder
+		Finally(call func()) TryBuilder
+		// Rethrow - raises the panic again as *PanicError after finally if no clause matched it.
+		Rethrow() TryBuilder
+		// Run - calls the function, the matched clause and finally. It returns panics raised
+		// in handlers and finally, and the panic no clause matched unless Rethrow was set.
+		Run() error
+	}
+
+	_tryBuilder struct {
+		call    func()
+		clauses []CatchClause
+		finally []func()
+		rethrow bool
+	}
+)
+
+// TryE - creates a builder of typed catch clauses for call.
+func TryE(call func()) TryBuilder {
+	return &_tryBuilder{call: call}
+}
+
+// CatchAs - clause that matches panics with a value of type T, or with an error value
+// that errors.As can convert to T.
+func CatchAs[T any](handler func(value T)) CatchClause {
+	typ := reflect.TypeFor[T]()
+	useAs := typ.Kind() == reflect.Interface || typ.Implements(reflect.TypeFor[error]())
+
+	return func(value any, _ *PanicError) bool {
+		if v, ok := value.(T); ok {
+			handler(v)
+			return true
+		}
+		var target T
+		if err, ok := value.(error); ok && useAs && errors.As(err, &target) {
+			handler(target)
+			return true
+		}
+		return false
+	}
+}
+
+func (v *_tryBuilder) Catch(clause CatchClause) TryBuilder {
+	if clause != nil {
+		v.clauses = append(v.clauses, clause)
+	}
+	return v
+}
+
+func (v *_tryBuilder) CatchValue(handler func(value any)) TryBuilder {
+	if handler == nil {
+		return v
+	}
+	return v.Catch(func(value any, _ *PanicError) bool {
+		handler(value)
+		return true
+	})
+}
+
+func (v *_tryBuilder) Finally(call func()) TryBuilder {
+	if call != nil {
+		v.finally = append(v.finally, call)
+	}
+	return v
+}
+
+func (v *_tryBuilder) Rethrow() TryBuilder {
+	v.rethrow = true
+	return v
+}
+
+func (v *_tryBuilder) Run() error {
+	var errs []error
+
+	var pe *PanicError
+	if v.call != nil {
+		if err := Recovery(v.call); err != nil {
+			errors.As(err, &pe)
+		}
+	}
+
+	matched := pe == nil
+	if pe != nil {
+		value := pe.Value
+		for {
+			inner, ok := value.(*PanicError)
+			if !ok {
+				break
+			}
+			value = inner.Value
+		}
+
+		for _, clause := range v.clauses {
+			if err := Recovery(func() {
+				matched = clause(value, pe)
+			}); err != nil {
+				matched = true
+				errs = append(errs, err)
+			}
+			if matched {
+				break
+			}
+		}
+	}
+
+	for _, call := range v.finally {
+		if err := Recovery(call); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !matched {
+		if v.rethrow {
+			panic(pe)
+		}
+		errs = append([]error{pe}, errs...)
+	}
+	return errors.Join(errs...)
+}
@@ -8,6 +8,7 @@ package do_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"go.osspkg.com/casecheck"
@@ -32,3 +33,101 @@ func TestUnit_Try(t *testing.T) {
 	})
 	casecheck.Equal(t, `1+catch+finally`, errs.Error())
 }
+
+type tryTestError struct {
+	code int
+}
+
+func (e *tryTestError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func TestUnit_TryE(t *testing.T) {
+	var calls []string
+
+	err := do.TryE(func() {
+		panic(fmt.Errorf("wrap: %w", &tryTestError{code: 7}))
+	}).Catch(do.CatchAs[string](func(v string) {
+		calls = append(calls, "string")
+	})).Catch(do.CatchAs[*tryTestError](func(e *tryTestError) {
+		calls = append(calls, fmt.Sprintf("err %d", e.code))
+	})).CatchValue(func(v any) {
+		calls = append(calls, "value")
+	}).Finally(func() {
+		calls = append(calls, "finally")
+	}).Run()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"err 7", "finally"}, calls)
+
+	calls = nil
+	err = do.TryE(func() {
+		panic("boom")
+	}).Catch(do.CatchAs[error](func(e error) {
+		calls = append(calls, "error")
+	})).Catch(do.CatchAs[string](func(v string) {
+		calls = append(calls, v)
+	})).Run()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"boom"}, calls)
+
+	calls = nil
+	err = do.TryE(func() {
+		calls = append(calls, "call")
+	}).CatchValue(func(v any) {
+		calls = append(calls, "value")
+	}).Finally(func() {
+		calls = append(calls, "finally")
+	}).Run()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, []string{"call", "finally"}, calls)
+}
+
+func TestUnit_TryEUnmatched(t *testing.T) {
+	err := do.TryE(func() {
+		panic(42)
+	}).Catch(do.CatchAs[string](func(string) {})).Run()
+	var pe *do.PanicError
+	casecheck.True(t, errors.As(err, &pe))
+	casecheck.Equal(t, 42, pe.Value)
+
+	finally := false
+	perr := do.Recovery(func() {
+		//nolint:errcheck
+		do.TryE(func() {
+			panic(42)
+		}).Catch(do.CatchAs[string](func(string) {})).Finally(func() {
+			finally = true
+		}).Rethrow().Run()
+	})
+	casecheck.True(t, finally)
+	casecheck.True(t, errors.As(perr, &pe))
+	casecheck.True(t, errors.As(pe.Unwrap(), &pe))
+	casecheck.Equal(t, 42, pe.Value)
+
+	var value any
+	err = do.TryE(func() {
+		do.TryE(func() {
+			panic(42)
+		}).Rethrow().Run() //nolint:errcheck
+	}).Catch(do.CatchAs[int](func(v int) {
+		value = v
+	})).Run()
+	casecheck.NoError(t, err)
+	casecheck.Equal(t, 42, value)
+}
+
+func TestUnit_TryEHandlerPanic(t *testing.T) {
+	err := do.TryE(func() {
+		panic("boom")
+	}).CatchValue(func(any) {
+		panic("catch")
+	}).CatchValue(func(any) {
+		t.Fatal("must not be called")
+	}).Finally(func() {
+		panic("finally")
+	}).Run()
+	casecheck.Error(t, err)
+	casecheck.Contains(t, err.Error(), "panic=catch")
+	casecheck.Contains(t, err.Error(), "panic=finally")
+	casecheck.False(t, strings.Contains(err.Error(), "panic=boom"))
+}
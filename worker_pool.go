@@ -0,0 +1,173 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrPoolClosed    = errors.New("worker pool is closed")
+	ErrPoolQueueFull = errors.New("worker pool queue is full")
+)
+
+type (
+	WorkerPool interface {
+		// Submit - waits for a free place in the queue until ctx is done.
+		Submit(ctx context.Context, task func(ctx context.Context) error) error
+		// TrySubmit - returns ErrPoolQueueFull instead of waiting.
+		TrySubmit(task func(ctx context.Context) error) error
+		// Shutdown - stops accepting tasks and waits until the queue is drained or ctx is done.
+		Shutdown(ctx context.Context) error
+		// Stop - stops accepting tasks, drops the queue and cancels the context of running tasks.
+		Stop()
+		Workers() int
+		QueueLen() int
+		Busy() int
+	}
+
+	_workerPool struct {
+		queue   chan func(ctx context.Context) error
+		errFunc func(err error)
+		workers int
+		busy    atomic.Int64
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		closing   chan struct{}
+		closeOnce sync.Once
+		closed    bool
+		mux       sync.RWMutex
+		wg        sync.WaitGroup
+	}
+)
+
+// NewWorkerPool - starts workers that run tasks from a queue of queueSize,
+// task errors and recovered panics are passed to errFunc.
+func NewWorkerPool(workers, queueSize int, errFunc func(err error)) WorkerPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &_workerPool{
+		queue:   make(chan func(ctx context.Context) error, max(queueSize, 0)),
+		errFunc: errFunc,
+		workers: max(workers, 1),
+		ctx:     ctx,
+		cancel:  cancel,
+		closing: make(chan struct{}),
+	}
+
+	p.wg.Add(p.workers)
+	for i := 0; i < p.workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+func (p *_workerPool) worker() {
+	defer p.wg.Done()
+
+	for task := range p.queue {
+		if p.ctx.Err() != nil {
+			continue
+		}
+
+		p.busy.Add(1)
+		var err error
+		if e := Recovery(func() {
+			err = task(p.ctx)
+		}); e != nil {
+			err = e
+		}
+		p.busy.Add(-1)
+
+		if err != nil && p.errFunc != nil {
+			p.errFunc(err)
+		}
+	}
+}
+
+func (p *_workerPool) Submit(ctx context.Context, task func(ctx context.Context) error) error {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case <-p.closing:
+		return ErrPoolClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.queue <- task:
+		return nil
+	}
+}
+
+func (p *_workerPool) TrySubmit(task func(ctx context.Context) error) error {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.queue <- task:
+		return nil
+	default:
+		return ErrPoolQueueFull
+	}
+}
+
+func (p *_workerPool) close() {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+
+		p.mux.Lock()
+		p.closed = true
+		close(p.queue)
+		p.mux.Unlock()
+	})
+}
+
+func (p *_workerPool) Shutdown(ctx context.Context) error {
+	p.close()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *_workerPool) Stop() {
+	p.cancel()
+	p.close()
+}
+
+func (p *_workerPool) Workers() int {
+	return p.workers
+}
+
+func (p *_workerPool) QueueLen() int {
+	return len(p.queue)
+}
+
+func (p *_workerPool) Busy() int {
+	return int(p.busy.Load())
+}
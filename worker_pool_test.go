@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2024-2025 Mikhail Knyazhev <markus621@yandex.com>. All rights reserved.
+ *  Use of this source code is governed by a BSD 3-Clause license that can be found in the LICENSE file.
+ */
+
+package do_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.osspkg.com/casecheck"
+
+	"go.osspkg.com/do"
+)
+
+func TestUnit_WorkerPool(t *testing.T) {
+	var (
+		done int64
+		errs atomic.Int64
+	)
+	pool := do.NewWorkerPool(2, 10, func(err error) {
+		var pe *do.PanicError
+		if errors.As(err, &pe) || err.Error() == "fail" {
+			errs.Add(1)
+		}
+	})
+	casecheck.Equal(t, 2, pool.Workers())
+
+	for i := 0; i < 10; i++ {
+		casecheck.NoError(t, pool.Submit(context.TODO(), func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&done, 1)
+			switch i {
+			case 3:
+				panic("boom")
+			case 5:
+				return fmt.Errorf("fail")
+			}
+			return nil
+		}))
+	}
+
+	casecheck.NoError(t, pool.Shutdown(context.TODO()))
+	casecheck.Equal(t, int64(10), atomic.LoadInt64(&done))
+	casecheck.Equal(t, int64(2), errs.Load())
+	casecheck.Equal(t, 0, pool.QueueLen())
+	casecheck.Equal(t, 0, pool.Busy())
+
+	casecheck.True(t, errors.Is(pool.Submit(context.TODO(), nil), do.ErrPoolClosed))
+	casecheck.True(t, errors.Is(pool.TrySubmit(nil), do.ErrPoolClosed))
+}
+
+func TestUnit_WorkerPoolBackpressure(t *testing.T) {
+	release := make(chan struct{})
+	pool := do.NewWorkerPool(1, 1, nil)
+
+	casecheck.NoError(t, pool.Submit(context.TODO(), func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+	for pool.Busy() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	casecheck.NoError(t, pool.TrySubmit(func(ctx context.Context) error { return nil }))
+	casecheck.Equal(t, 1, pool.QueueLen())
+	casecheck.True(t, errors.Is(pool.TrySubmit(nil), do.ErrPoolQueueFull))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	casecheck.True(t, errors.Is(pool.Submit(ctx, nil), context.DeadlineExceeded))
+
+	ctx, cancel = context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	casecheck.True(t, errors.Is(pool.Shutdown(ctx), context.DeadlineExceeded))
+
+	close(release)
+	casecheck.NoError(t, pool.Shutdown(context.TODO()))
+}
+
+func TestUnit_WorkerPoolStop(t *testing.T) {
+	var done int64
+	started := make(chan struct{})
+	pool := do.NewWorkerPool(1, 5, nil)
+
+	casecheck.NoError(t, pool.Submit(context.TODO(), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	<-started
+	for i := 0; i < 5; i++ {
+		casecheck.NoError(t, pool.TrySubmit(func(ctx context.Context) error {
+			atomic.AddInt64(&done, 1)
+			return nil
+		}))
+	}
+
+	pool.Stop()
+	casecheck.NoError(t, pool.Shutdown(context.TODO()))
+	casecheck.Equal(t, int64(0), atomic.LoadInt64(&done))
+}